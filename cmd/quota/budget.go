@@ -0,0 +1,130 @@
+package quota
+
+import (
+	"time"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/display"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+	"github.com/JValdivia23/quota-cli/pkg/pricing"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+// budgetCmd represents the budget command
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Compare this month's spend with your budgets",
+	Long: `Shows every provider's spend this calendar month, the spend projected by
+month end at the current burn rate and, where a budget is configured, how
+much of it is left and whether the provider is on track, at risk of going
+over or already over.
+
+Spend is the month-to-date cost of pay-as-you-go providers and, for
+providers with a price (see the pricing.* config keys), the cost of usage
+beyond the entitlement. Set budgets with:
+
+  qcli config set providers.openrouter.budget 50
+  qcli config set budget.monthly 200`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, active, err := discoverProviders()
+		if err != nil {
+			return err
+		}
+
+		reports := providers.FetchAll(cmd.Context(), active, cfg, fetchOptions(cmd, active))
+		now := time.Now().In(userCalendar().Loc())
+		store := openHistory()
+		recordSnapshots(store, reports, now)
+		fillMonthToDate(store, reports, now)
+		if store != nil {
+			store.Close()
+		}
+		applyBudgets(reports, now)
+		applyDisplaySettings(reports)
+
+		rows, total := budgetSummary(reports, now)
+
+		if useJSON(cmd) {
+			display.PrintBudgetJSON(rows, total)
+		} else {
+			display.PrintBudget(rows, total)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(budgetCmd)
+}
+
+// applyBudgets sets the budget status of every provider with a monthly budget.
+func applyBudgets(reports []*models.ProviderReport, now time.Time) {
+	prices := config.Pricing()
+	for _, rep := range reports {
+		limit := config.ProviderBudget(rep.ID)
+		if rep.ErrorMsg != "" || limit <= 0 {
+			continue
+		}
+		spent, _ := monthSpend(rep, prices)
+		rep.Budget = predictor.EvaluateBudget(spent, limit, now)
+	}
+}
+
+// budgetSummary returns a row for every provider with a budget or a known
+// spend, and their total measured against the overall monthly budget.
+func budgetSummary(reports []*models.ProviderReport, now time.Time) ([]display.BudgetRow, models.BudgetStatus) {
+	prices := config.Pricing()
+	var rows []display.BudgetRow
+	var spent float64
+	for _, rep := range reports {
+		if rep.ErrorMsg != "" {
+			continue
+		}
+		status := rep.Budget
+		if status == nil {
+			s, ok := monthSpend(rep, prices)
+			if !ok {
+				continue
+			}
+			status = predictor.EvaluateBudget(s, 0, now)
+		}
+		rows = append(rows, display.BudgetRow{Provider: rep.Name, Budget: *status})
+		spent += status.Spent
+	}
+	return rows, *predictor.EvaluateBudget(spent, config.MonthlyBudget(), now)
+}
+
+// monthSpend returns what a provider has cost so far this calendar month, and
+// false when that cannot be known (no price for its usage).
+func monthSpend(rep *models.ProviderReport, prices *pricing.Table) (float64, bool) {
+	if rep.Type == models.TypePayAsYouGo {
+		return rep.MonthToDate, true
+	}
+	price, ok := prices.Lookup(rep.ID, rep.Plan)
+	if !ok {
+		return 0, false
+	}
+	switch rep.Type {
+	case models.TypeTokensBased:
+		return price.Cost(float64(rep.TokensUsed)), true
+	case models.TypeQuotaBased:
+		// Only usage beyond the entitlement is billed; providers that allow
+		// overage report it as negative remaining.
+		if rep.Remaining < 0 {
+			return price.Cost(float64(-rep.Remaining)), true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// displayName returns the configured display name of a provider ID, falling
+// back to the built-in one.
+func displayName(id string) string {
+	if n := config.ProviderName(id); n != "" {
+		return n
+	}
+	return providers.NameOf(id)
+}
@@ -0,0 +1,53 @@
+package quota
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/cache"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+)
+
+var (
+	noCache      bool
+	refreshCache bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "neither read nor write the report cache")
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "fetch every provider now, ignoring cached reports, and update the cache")
+}
+
+// cacheProviders serves the providers' reports from the on-disk cache unless
+// it is disabled by --no-cache or cache.enabled. A cache that cannot be
+// opened only costs the fetches it would have saved.
+func cacheProviders(active []providers.Provider) []providers.Provider {
+	if noCache || !config.CacheEnabled() {
+		return active
+	}
+	dir := config.CachePath()
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: cache disabled:", err)
+			return active
+		}
+	}
+	store, err := cache.Open(dir, config.CacheMaxAge())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: cache disabled:", err)
+		return active
+	}
+
+	cached := make([]providers.Provider, len(active))
+	for i, p := range active {
+		cached[i] = cache.Wrap(p, store, cache.Policy{
+			TTL:          config.CacheTTL(p.ID()),
+			Refresh:      refreshCache,
+			StaleOnError: config.CacheStaleOnError(),
+			MaxAge:       config.CacheMaxAge(),
+		})
+	}
+	return cached
+}
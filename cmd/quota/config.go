@@ -0,0 +1,180 @@
+package quota
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage configuration settings",
+	Long: `Manage qcli settings stored in ~/.quota-cli.yaml (or the file given by --config).
+
+Supported keys ("*" is a provider ID such as copilot, openai or vertex; aliases
+such as github-copilot are accepted and stored under the ID):
+
+` + config.Describe() + `
+Every key can also be set through the environment with a QCLI_ prefix,
+e.g. QCLI_OUTPUT=json or QCLI_PROVIDERS_OPENAI_ENABLED=false.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := config.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		if v, ok := f.Get(args[0]); ok {
+			fmt.Println(v)
+			return nil
+		}
+		if k.Default != "" {
+			fmt.Println(k.Default)
+			return nil
+		}
+		return fmt.Errorf("%s is not set", args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration key",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := f.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		return f.Save()
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration key, restoring its default",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		if !f.Unset(args[0]) {
+			return fmt.Errorf("%s is not set", args[0])
+		}
+		return f.Save()
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all keys set in the configuration file",
+	Long: `Lists every key set in the configuration file. Invalid keys are reported
+as warnings on stderr when the file is loaded.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		for _, e := range f.Entries() {
+			fmt.Printf("%s=%s\n", e.Key, e.Value)
+		}
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the configuration file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the configuration file in $VISUAL or $EDITOR",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFilePath()
+		if err != nil {
+			return err
+		}
+
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+			if runtime.GOOS == "windows" {
+				editor = "notepad"
+			}
+		}
+
+		c := exec.Command(editor, path)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("editor %q failed: %w", editor, err)
+		}
+
+		// Validate what the user saved so mistakes surface immediately.
+		f, err := config.Load(path)
+		if err != nil {
+			return err
+		}
+		if errs := f.Validate(); len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+			}
+			return fmt.Errorf("%s has %d invalid key(s)", path, len(errs))
+		}
+		return nil
+	},
+}
+
+// configFilePath returns the file the config subcommands operate on.
+func configFilePath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	return config.DefaultPath()
+}
+
+func loadConfigFile() (*config.File, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return config.Load(path)
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configListCmd, configPathCmd, configEditCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,118 @@
+package quota
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/display"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forecastModel string
+	backtestDays  int
+)
+
+// forecastCmd represents the forecast command
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast usage at each quota reset",
+	Long: `Forecasts every active provider's usage at the end of its quota window
+with the model chosen by --model, or each provider's forecast_model setting.
+
+Models: weighted (7-day weighted average with weekend compensation),
+ewma (exponentially weighted moving average), linear (least-squares trend)
+and holt-winters (trend plus weekly seasonality; needs 14 days of history).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if forecastModel != "" {
+			if _, err := predictor.NewForecaster(forecastModel); err != nil {
+				return err
+			}
+		}
+		cal := userCalendar()
+		reports, err := fetchReports(cmd, cal)
+		if err != nil {
+			return err
+		}
+		if err := predict(reports, forecastModel, cal); err != nil {
+			return err
+		}
+
+		applyDisplaySettings(reports)
+
+		if useJSON(cmd) {
+			display.PrintJSON(reports)
+		} else {
+			display.PrintForecast(reports)
+		}
+		return nil
+	},
+}
+
+// backtestCmd represents the forecast backtest command
+var backtestCmd = &cobra.Command{
+	Use:   "backtest",
+	Short: "Score every forecasting model against recorded history",
+	Long: `Replays the daily usage derived from recorded snapshots: every day after
+the first week is forecast from the days before it, and the mean absolute
+percentage error (MAPE) of each model is reported per provider. Lower is
+better; use the best model with 'qcli config set providers.<id>.forecast_model'.
+
+No provider is queried, so this works offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := openHistory()
+		if store == nil {
+			return fmt.Errorf("backtesting needs recorded history; enable it with 'qcli config set history.enabled true'")
+		}
+		defer store.Close()
+
+		sel, err := providerSelection()
+		if err != nil {
+			return err
+		}
+		ids, err := store.Providers()
+		if err != nil {
+			return err
+		}
+
+		cal := userCalendar()
+		now := time.Now().In(cal.Loc())
+		var rows []display.BacktestRow
+		for _, id := range ids {
+			if !sel.Match(providers.NameOf(id)) {
+				continue
+			}
+			days, err := store.DailyUsage(id, backtestDays, now)
+			if err != nil {
+				return err
+			}
+			row := display.BacktestRow{Provider: displayName(id), Days: len(days)}
+			for _, model := range predictor.Models() {
+				f, _ := predictor.NewForecaster(model)
+				row.Results = append(row.Results, predictor.Backtest(f, days, cal))
+			}
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("no recorded history yet; run 'qcli status' regularly to build it")
+		}
+
+		if useJSON(cmd) {
+			display.PrintBacktestJSON(rows)
+		} else {
+			display.PrintBacktest(rows)
+		}
+		return nil
+	},
+}
+
+func init() {
+	forecastCmd.Flags().StringVar(&forecastModel, "model", "",
+		fmt.Sprintf("forecasting model for every provider (%s)", strings.Join(predictor.Models(), ", ")))
+	backtestCmd.Flags().IntVar(&backtestDays, "days", 60, "days of history to replay")
+	forecastCmd.AddCommand(backtestCmd)
+	rootCmd.AddCommand(forecastCmd)
+}
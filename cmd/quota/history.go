@@ -0,0 +1,135 @@
+package quota
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/history"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+)
+
+// historyDays is how many days of history are derived from snapshots: four
+// weeks, so seasonal forecasting models see more than one week.
+const historyDays = 28
+
+// openHistory opens the snapshot store, or returns nil when history is
+// disabled or unavailable. Problems are only warned about: a broken history
+// database must never stop quotas from being shown.
+func openHistory() *history.Store {
+	if !config.HistoryEnabled() {
+		return nil
+	}
+	path := config.HistoryPath()
+	if path == "" {
+		var err error
+		if path, err = history.DefaultPath(); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: history disabled:", err)
+			return nil
+		}
+	}
+	store, err := history.Open(path, config.HistoryRetention())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: history disabled:", err)
+		return nil
+	}
+	return store
+}
+
+// userCalendar returns the configured calendar. A broken setting is only
+// warned about and the local time zone with a Monday–Friday week is used.
+func userCalendar() predictor.Calendar {
+	cal, err := config.Calendar()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: ignoring calendar settings:", err)
+	}
+	return cal
+}
+
+// recordSnapshots stores every successful report, keyed by provider ID.
+func recordSnapshots(store *history.Store, reports []*models.ProviderReport, now time.Time) {
+	if store == nil {
+		return
+	}
+	for _, rep := range reports {
+		if rep.CachedAt != nil {
+			continue // recorded when it was fetched
+		}
+		if err := store.Save(rep, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not record %s snapshot: %v\n", rep.Name, err)
+		}
+	}
+}
+
+// fillHistory derives daily usage from snapshots for providers that returned
+// no server-side history.
+func fillHistory(store *history.Store, reports []*models.ProviderReport, now time.Time) {
+	if store == nil {
+		return
+	}
+	for _, rep := range reports {
+		if rep.ErrorMsg != "" || len(rep.History) > 0 {
+			continue
+		}
+		days, err := store.DailyUsage(rep.ID, historyDays, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read %s history: %v\n", rep.Name, err)
+			continue
+		}
+		rep.History = days
+	}
+}
+
+// fillMonthToDate derives month-to-date spend from snapshots for
+// pay-as-you-go providers that only report a lifetime total.
+func fillMonthToDate(store *history.Store, reports []*models.ProviderReport, now time.Time) {
+	if store == nil {
+		return
+	}
+	for _, rep := range reports {
+		if rep.ErrorMsg != "" || rep.Type != models.TypePayAsYouGo || rep.MonthToDate != 0 {
+			continue
+		}
+		mtd, err := store.MonthToDate(rep.ID, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read %s history: %v\n", rep.Name, err)
+			continue
+		}
+		rep.MonthToDate = mtd
+	}
+}
+
+// estimateExhaustion sets when each quota-based provider and account runs out,
+// from the burn rate over recent snapshots or, without them, daily history.
+// store may be nil.
+func estimateExhaustion(store *history.Store, reports []*models.ProviderReport, now time.Time) {
+	for _, rep := range reports {
+		if rep.ErrorMsg != "" || rep.Type != models.TypeQuotaBased {
+			continue
+		}
+		var series map[string][]predictor.Sample
+		if store != nil {
+			var err error
+			if series, err = store.UsageSeries(rep.ID, now.Add(-24*time.Hour)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not read %s history: %v\n", rep.Name, err)
+			}
+		}
+
+		if rep.Entitlement > 0 {
+			rate := predictor.BurnRate(series[history.SeriesKey("", rep.Windows)], now)
+			if rate == 0 {
+				rate = predictor.DailyBurnRate(rep.History)
+			}
+			rep.Exhaustion = predictor.EstimateExhaustion(float64(rep.Remaining), rate, now, rep.ResetAt)
+		}
+		for i := range rep.Accounts {
+			acc := &rep.Accounts[i]
+			if acc.Entitlement > 0 {
+				rate := predictor.BurnRate(series[history.SeriesKey(history.AccountKey(*acc), acc.Windows)], now)
+				acc.Exhaustion = predictor.EstimateExhaustion(float64(acc.Remaining), rate, now, acc.ResetAt)
+			}
+		}
+	}
+}
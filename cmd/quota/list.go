@@ -0,0 +1,46 @@
+package quota
+
+import (
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/auth"
+	"github.com/JValdivia23/quota-cli/pkg/display"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List supported providers and where their credentials were found",
+	Long: `Lists every supported provider, whether credentials were detected for it,
+and exactly where they came from (auth.json path, environment variable,
+antigravity-accounts.json, opencode.db or Google ADC). Secrets are masked.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sel, err := providerSelection()
+		if err != nil {
+			return err
+		}
+		cfg, err := auth.DiscoverOpenCodeAuth()
+		if err != nil {
+			cfg = &models.OpenCodeAuthConfig{RawKeys: make(map[string]interface{})}
+		}
+
+		var detections []providers.Detection
+		for _, d := range providers.DetectProviders(cfg, config.ProviderSettings()) {
+			if sel.Match(d.Name) {
+				detections = append(detections, d)
+			}
+		}
+		if useJSON(cmd) {
+			display.PrintDetectionsJSON(detections)
+		} else {
+			display.PrintDetections(detections)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,100 @@
+package quota
+
+import (
+	"time"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/display"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a detailed usage report",
+	Long: `Fetches current usage plus the last 7 days of history for every active
+provider and prints a per-provider breakdown with daily usage, totals,
+trend and a forecast of usage when each quota resets (the Claude and
+OpenAI weekly windows, the Copilot reset date), or at month end otherwise.
+
+Providers without server-side history get one derived from the snapshots
+qcli records on every run (see the history.* config keys).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cal := userCalendar()
+		reports, err := fetchReports(cmd, cal)
+		if err != nil {
+			return err
+		}
+		if err := predict(reports, "", cal); err != nil {
+			return err
+		}
+
+		applyDisplaySettings(reports)
+
+		if useJSON(cmd) {
+			display.PrintJSON(reports)
+		} else {
+			display.PrintReport(reports)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+}
+
+// fetchReports fetches current usage and history for the active providers,
+// records snapshots and fills in derived history, exhaustion estimates and
+// budget status.
+// Derived days start at midnight in the calendar's time zone.
+func fetchReports(cmd *cobra.Command, cal predictor.Calendar) ([]*models.ProviderReport, error) {
+	cfg, active, err := discoverProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := providers.FetchAllWithHistory(cmd.Context(), active, cfg, fetchOptions(cmd, active))
+	now := time.Now().In(cal.Loc())
+	store := openHistory()
+	recordSnapshots(store, reports, now)
+	fillHistory(store, reports, now)
+	fillMonthToDate(store, reports, now)
+	estimateExhaustion(store, reports, now)
+	if store != nil {
+		store.Close()
+	}
+	applyBudgets(reports, now)
+	return reports, nil
+}
+
+// predict attaches a forecast to every successful report. model overrides the
+// per-provider forecast_model setting when not empty.
+func predict(reports []*models.ProviderReport, model string, cal predictor.Calendar) error {
+	prices := config.Pricing()
+	for _, rep := range reports {
+		if rep.ErrorMsg != "" {
+			continue
+		}
+		id := rep.ID
+
+		name := model
+		if name == "" {
+			name = config.ProviderForecastModel(id)
+		}
+		forecaster, err := predictor.NewForecaster(name)
+		if err != nil {
+			return err
+		}
+
+		opts := predictor.Options{Model: forecaster, Calendar: cal}
+		if p, ok := prices.Lookup(id, rep.Plan); ok {
+			opts.Price = &p
+		}
+		rep.Prediction = predictor.CalculatePrediction(rep.History, rep, opts)
+	}
+	return nil
+}
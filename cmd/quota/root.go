@@ -0,0 +1,135 @@
+package quota
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	cfgFile       string
+	jsonOutput    bool
+	providerFlags []string
+	excludeFlags  []string
+	fetchTimeout  time.Duration
+)
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "qcli",
+	Short: "A CLI tool to fetch and display AI provider quota usage.",
+	Long: `qcli is a fast, cross-platform tool developed in Go that auto-discovers
+your AI provider credentials and reports quota and cost in a single table.
+
+Running 'qcli' without arguments is the same as 'qcli status'.`,
+	RunE:         runStatus,
+	SilenceUsage: true,
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// SetVersion records the build information shown by --version and sent in
+// the User-Agent of API requests. It is called by main.main.
+func SetVersion(version, commit, date string) {
+	buildVersion = version
+	rootCmd.Version = fmt.Sprintf("%s (commit %s, built %s)", version, commit, date)
+}
+
+// buildVersion is the release qcli was built as, or "dev".
+var buildVersion = "dev"
+
+// userAgent identifies qcli to provider APIs.
+func userAgent() string {
+	return "qcli/" + buildVersion + " (+https://github.com/JValdivia23/quota-cli)"
+}
+
+func init() {
+	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.quota-cli.yaml)")
+	rootCmd.PersistentFlags().BoolVarP(&jsonOutput, "json", "j", false, "output as JSON (default from the 'output' config key)")
+	rootCmd.PersistentFlags().StringSliceVarP(&providerFlags, "provider", "p", nil, "only query these providers: IDs, aliases or globs, comma-separated or repeated")
+	rootCmd.PersistentFlags().StringSliceVarP(&excludeFlags, "exclude", "x", nil, "skip these providers: IDs, aliases or globs")
+	for _, flag := range []string{"provider", "exclude"} {
+		cobra.CheckErr(rootCmd.RegisterFlagCompletionFunc(flag, completeProviders))
+	}
+	rootCmd.PersistentFlags().DurationVar(&fetchTimeout, "timeout", providers.DefaultTimeout, "per-provider fetch timeout (default from the 'timeout' config key)")
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig() {
+	if cfgFile != "" {
+		// Use config file from the flag.
+		viper.SetConfigFile(cfgFile)
+	} else {
+		// Find home directory.
+		home, err := os.UserHomeDir()
+		cobra.CheckErr(err)
+
+		// Search config in home directory with name ".quota-cli" (without extension).
+		viper.AddConfigPath(home)
+		viper.SetConfigType("yaml")
+		viper.SetConfigName(".quota-cli")
+	}
+
+	// Environment overrides use the QCLI_ prefix, e.g. QCLI_OUTPUT=json.
+	viper.SetEnvPrefix("QCLI")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viper.AutomaticEnv() // read in environment variables that match
+
+	// If a config file is found, read it in.
+	if err := viper.ReadInConfig(); err == nil {
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+		warnInvalidConfig(viper.ConfigFileUsed())
+	}
+}
+
+// warnInvalidConfig reports unknown or malformed keys without aborting, so a
+// typo in the config file never stops the quota check itself.
+func warnInvalidConfig(path string) {
+	f, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+		return
+	}
+	for _, err := range f.Validate() {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+}
+
+// providerSelection returns the providers picked with --provider and --exclude.
+func providerSelection() (providers.Selection, error) {
+	sel := providers.Selection{Include: providerFlags, Exclude: excludeFlags}
+	return sel, sel.Validate()
+}
+
+// completeProviders completes --provider and --exclude with every provider ID
+// and alias, including after a comma in "copilot,op".
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	done, prefix := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		done, prefix = toComplete[:i+1], toComplete[i+1:]
+	}
+	var out []string
+	for _, id := range providers.IDs() {
+		for _, key := range providers.Keys(id) {
+			if strings.HasPrefix(key, strings.ToLower(prefix)) {
+				out = append(out, done+key+"\t"+providers.NameOf(id))
+			}
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
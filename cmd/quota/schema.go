@@ -0,0 +1,35 @@
+package quota
+
+import (
+	"os"
+
+	"github.com/JValdivia23/quota-cli/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd represents the schema command
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of --json output",
+	Long: `Prints the JSON Schema (draft 2020-12) describing the document that
+'qcli status', 'qcli report' and 'qcli forecast' print with --json.
+
+The document's schemaVersion field names the schema it follows; it is bumped
+whenever a field is added, renamed or removed.
+
+The --json output of 'qcli list', 'qcli budget' and 'qcli forecast backtest'
+is not covered: it is meant for inspection and may change between releases.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := schema.Current()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(b)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,168 @@
+package quota
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/internal/config"
+	"github.com/JValdivia23/quota-cli/pkg/auth"
+	"github.com/JValdivia23/quota-cli/pkg/display"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show quota and cost for every discovered provider",
+	Long: `Discovers credentials for every supported provider, queries them all
+concurrently and prints a single table (or JSON with --json).
+
+Providers that fail or time out are listed with their error instead of
+aborting the whole run.`,
+	RunE: runStatus,
+}
+
+var (
+	// modelFilter narrows per-model quota buckets to models whose name contains it.
+	modelFilter string
+	// allModels lists every model quota bucket instead of only the tightest.
+	allModels bool
+)
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	for _, c := range []*cobra.Command{rootCmd, statusCmd} {
+		c.Flags().StringVarP(&modelFilter, "model", "m", "", "only show quota buckets of models whose name contains this (e.g. pro, flash)")
+		c.Flags().BoolVar(&allModels, "all-models", false, "show every model quota bucket, not only the tightest")
+	}
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	cfg, active, err := discoverProviders()
+	if err != nil {
+		return err
+	}
+
+	reports := providers.FetchAll(cmd.Context(), active, cfg, fetchOptions(cmd, active))
+	now := time.Now().In(userCalendar().Loc())
+	store := openHistory()
+	recordSnapshots(store, reports, now)
+	fillMonthToDate(store, reports, now)
+	estimateExhaustion(store, reports, now)
+	if store != nil {
+		store.Close()
+	}
+	applyBudgets(reports, now)
+	applyDisplaySettings(reports)
+	if modelFilter != "" {
+		if err := filterModels(reports, modelFilter); err != nil {
+			return err
+		}
+	}
+	display.AllModels = allModels || modelFilter != ""
+
+	if useJSON(cmd) {
+		display.PrintJSON(reports)
+	} else {
+		display.PrintTable(reports)
+	}
+	return nil
+}
+
+// discoverProviders loads credentials and resolves the active provider set,
+// honouring --provider and --exclude, and sets up the HTTP client and report
+// cache providers fetch with.
+func discoverProviders() (*models.OpenCodeAuthConfig, []providers.Provider, error) {
+	sel, err := providerSelection()
+	if err != nil {
+		return nil, nil, err
+	}
+	httpCfg := config.HTTP()
+	httpCfg.UserAgent = userAgent()
+	if err := providers.ConfigureHTTP(httpCfg); err != nil {
+		return nil, nil, fmt.Errorf("http.ca_bundle: %w", err)
+	}
+
+	cfg, authErr := auth.DiscoverOpenCodeAuth()
+	if authErr != nil {
+		// Providers such as Vertex AI rely on ambient credentials, so keep going
+		// with an empty config and only report the error if nothing is found.
+		cfg = &models.OpenCodeAuthConfig{RawKeys: make(map[string]interface{})}
+	}
+
+	var active []providers.Provider
+	for _, p := range providers.GetActiveProviders(cfg, config.ProviderSettings(), sel) {
+		// An explicit --provider wins over enabled: false in the config.
+		if len(sel.Include) == 0 && !config.ProviderEnabled(p.ID()) {
+			continue
+		}
+		active = append(active, p)
+	}
+	if len(active) == 0 {
+		if len(sel.Include) > 0 {
+			return nil, nil, fmt.Errorf("no credentials found for provider %s", strings.Join(sel.Include, ", "))
+		}
+		if authErr != nil {
+			return nil, nil, authErr
+		}
+		return nil, nil, fmt.Errorf("no providers detected")
+	}
+	return cfg, cacheProviders(active), nil
+}
+
+// fetchOptions combines the --timeout flag with per-provider config timeouts.
+func fetchOptions(cmd *cobra.Command, active []providers.Provider) providers.FetchOptions {
+	opts := providers.FetchOptions{
+		Timeout:          config.Timeout(),
+		ProviderTimeouts: make(map[string]time.Duration),
+	}
+	if cmd.Flags().Changed("timeout") {
+		// An explicit flag applies to every provider.
+		opts.Timeout = fetchTimeout
+		return opts
+	}
+	for _, p := range active {
+		opts.ProviderTimeouts[p.ID()] = config.ProviderTimeout(p.ID())
+	}
+	return opts
+}
+
+// applyDisplaySettings applies configured display names, warning thresholds
+// and the time zone times are shown in.
+func applyDisplaySettings(reports []*models.ProviderReport) {
+	display.Location = config.Location()
+	for _, rep := range reports {
+		id := rep.ID
+		rep.Threshold = config.ProviderThreshold(id)
+		if name := config.ProviderName(id); name != "" {
+			rep.Name = name
+		}
+	}
+}
+
+// filterModels narrows every report to the model quota buckets whose name
+// contains pattern (see providers.NarrowModels). It fails when no provider
+// has a matching bucket.
+func filterModels(reports []*models.ProviderReport, pattern string) error {
+	matched := false
+	for _, rep := range reports {
+		if providers.NarrowModels(rep, pattern) {
+			matched = true
+		}
+	}
+	if !matched {
+		return fmt.Errorf("no model quota matches %q", pattern)
+	}
+	return nil
+}
+
+// useJSON reports whether JSON output was requested by flag or config.
+func useJSON(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("json") {
+		return jsonOutput
+	}
+	return config.Output() == "json"
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// File is a parsed config file. It keeps the YAML node tree so that comments
+// and key order survive a get/set/unset round trip.
+type File struct {
+	Path string
+	root *yaml.Node // mapping node
+	doc  *yaml.Node // document node
+}
+
+// DefaultPath returns ~/.quota-cli.yaml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".quota-cli.yaml"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty config.
+func Load(path string) (*File, error) {
+	f := &File{Path: path}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: top level must be a mapping", path)
+	}
+	f.doc, f.root = &doc, doc.Content[0]
+	return f, nil
+}
+
+// Save writes the config back to disk.
+func (f *File) Save() error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(f.doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, buf.Bytes(), 0o600)
+}
+
+// Get returns the value stored under a dotted key, or under the key with a
+// provider alias in its place (see CanonicalKey).
+func (f *File) Get(key string) (string, bool) {
+	if canon := CanonicalKey(key); canon != key {
+		if v, ok := f.get(canon); ok {
+			return v, true
+		}
+	}
+	return f.get(key)
+}
+
+func (f *File) get(key string) (string, bool) {
+	node := f.root
+	for _, seg := range strings.Split(key, ".") {
+		node = child(node, seg)
+		if node == nil {
+			return "", false
+		}
+	}
+	return nodeString(node), true
+}
+
+// Set validates value against the schema and stores it under key, with any
+// provider alias replaced by the provider's ID.
+func (f *File) Set(key, raw string) error {
+	k, err := Lookup(key)
+	if err != nil {
+		return err
+	}
+	value, err := k.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s %w", key, err)
+	}
+	key = CanonicalKey(key)
+
+	segs := strings.Split(key, ".")
+	node := f.root
+	for _, seg := range segs[:len(segs)-1] {
+		next := child(node, seg)
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, scalar(seg), next)
+		} else if next.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %q: %q is not a section", key, seg)
+		}
+		node = next
+	}
+
+	var valNode yaml.Node
+	if err := valNode.Encode(value); err != nil {
+		return err
+	}
+	leaf := segs[len(segs)-1]
+	if existing := child(node, leaf); existing != nil {
+		valNode.HeadComment, valNode.LineComment = existing.HeadComment, existing.LineComment
+		*existing = valNode
+		return nil
+	}
+	node.Content = append(node.Content, scalar(leaf), &valNode)
+	return nil
+}
+
+// Unset removes key, and the key with a provider alias in its place, pruning
+// any sections left empty. It reports whether the key was present.
+func (f *File) Unset(key string) bool {
+	removed := unset(f.root, strings.Split(key, "."))
+	if canon := CanonicalKey(key); canon != key {
+		removed = unset(f.root, strings.Split(canon, ".")) || removed
+	}
+	return removed
+}
+
+func unset(node *yaml.Node, segs []string) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != segs[0] {
+			continue
+		}
+		if len(segs) == 1 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+		sub := node.Content[i+1]
+		if sub.Kind != yaml.MappingNode || !unset(sub, segs[1:]) {
+			return false
+		}
+		if len(sub.Content) == 0 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// Entries flattens the file into sorted dotted keys and their values.
+func (f *File) Entries() []Entry {
+	var out []Entry
+	flatten(f.root, "", &out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// Entry is a single flattened key/value pair.
+type Entry struct {
+	Key   string
+	Value string
+}
+
+// Validate returns one error per key in the file that is not in the schema
+// or whose value does not parse.
+func (f *File) Validate() []error {
+	var errs []error
+	for _, e := range f.Entries() {
+		k, err := Lookup(e.Key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := k.Parse(e.Value); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", e.Key, err))
+		}
+	}
+	return errs
+}
+
+func flatten(node *yaml.Node, prefix string, out *[]Entry) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		val := node.Content[i+1]
+		if val.Kind == yaml.MappingNode {
+			flatten(val, key, out)
+			continue
+		}
+		*out = append(*out, Entry{Key: key, Value: nodeString(val)})
+	}
+}
+
+func child(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func nodeString(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	var v interface{}
+	if err := node.Decode(&v); err != nil {
+		return ""
+	}
+	if list, ok := v.([]interface{}); ok {
+		parts := make([]string, len(list))
+		for i, item := range list {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	}
+	b, _ := yaml.Marshal(v)
+	return strings.TrimSpace(string(b))
+}
+
+func scalar(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
@@ -0,0 +1,389 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+)
+
+// Kind is the value type accepted by a configuration key.
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindBool
+	KindInt
+	KindFloat
+	KindDuration
+	KindEnum
+	KindStringList
+	KindDate
+	KindTimezone
+	KindURL
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBool:
+		return "bool"
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "number"
+	case KindDuration:
+		return "duration"
+	case KindEnum:
+		return "enum"
+	case KindStringList:
+		return "list"
+	case KindDate:
+		return "date"
+	case KindTimezone:
+		return "timezone"
+	case KindURL:
+		return "url"
+	default:
+		return "string"
+	}
+}
+
+// Key describes one supported configuration key. A "*" segment in Pattern
+// matches a provider ID (see ProviderID); a "<name>" segment matches any name.
+type Key struct {
+	Pattern string
+	Kind    Kind
+	// Enum lists the allowed values of KindEnum and of KindStringList items,
+	// and the allowed schemes of KindURL (default http and https).
+	Enum    []string
+	Min     int
+	Max     int
+	Default string
+	Help    string
+	// LoopbackHTTP also allows plain http for a KindURL limited to https
+	// when the host is loopback, such as a local test server.
+	LoopbackHTTP bool
+}
+
+// Schema lists every key accepted in ~/.quota-cli.yaml.
+var Schema = []Key{
+	{Pattern: "output", Kind: KindEnum, Enum: []string{"table", "json"}, Default: "table",
+		Help: "default output format"},
+	{Pattern: "timeout", Kind: KindDuration, Default: "15s",
+		Help: "default per-provider fetch timeout"},
+	{Pattern: "threshold", Kind: KindInt, Min: 0, Max: 100, Default: "80",
+		Help: "usage percentage at which a provider is flagged (0 disables)"},
+	{Pattern: "http.connect_timeout", Kind: KindDuration, Default: "10s",
+		Help: "time allowed to connect and complete the TLS handshake"},
+	{Pattern: "http.timeout", Kind: KindDuration, Default: "15s",
+		Help: "time allowed for one API request, retries included"},
+	{Pattern: "http.retries", Kind: KindInt, Min: 0, Max: 10, Default: "2",
+		Help: "retries of requests failing with 429 or 5xx (0 disables)"},
+	{Pattern: "http.ca_bundle", Kind: KindString,
+		Help: "PEM file of extra certificate authorities to trust, e.g. a corporate proxy's"},
+	{Pattern: "providers.*.enabled", Kind: KindBool, Default: "true",
+		Help: "include the provider when it is detected"},
+	{Pattern: "providers.*.name", Kind: KindString,
+		Help: "display name shown instead of the built-in one"},
+	{Pattern: "providers.*.timeout", Kind: KindDuration,
+		Help: "fetch timeout for this provider (overrides timeout)"},
+	{Pattern: "providers.*.threshold", Kind: KindInt, Min: 0, Max: 100,
+		Help: "usage percentage at which this provider is flagged (overrides threshold)"},
+	{Pattern: "providers.*.forecast_model", Kind: KindEnum, Enum: predictor.Models(), Default: predictor.DefaultModel,
+		Help: "forecasting model used by report and forecast"},
+	{Pattern: "providers.*.budget", Kind: KindFloat,
+		Help: "monthly budget in USD for the provider"},
+	{Pattern: "providers.vertex.base_url", Kind: KindURL, Enum: []string{"https"},
+		Help: "Cloud Monitoring endpoint, e.g. a Private Service Connect address (https only)"},
+	{Pattern: "providers.antigravity.token_url", Kind: KindURL, Enum: []string{"https"}, LoopbackHTTP: true,
+		Help: "OAuth endpoint the antigravity refresh token is exchanged at (https, or http on localhost)"},
+	{Pattern: "providers.*.base_url", Kind: KindURL,
+		Help: "API base URL, e.g. a GitHub Enterprise Server or an internal gateway"},
+	{Pattern: "providers.*.cache_ttl", Kind: KindDuration,
+		Help: "how long this provider's report is reused (overrides cache.ttl)"},
+	{Pattern: "hpc.storage.paths", Kind: KindStringList,
+		Help: "Lustre mount points or GPFS devices to check (comma-separated)"},
+	{Pattern: "hpc.storage.projects", Kind: KindStringList,
+		Help: "group/project names whose storage quotas are also shown"},
+	{Pattern: "hpc.storage.tool", Kind: KindEnum, Enum: []string{"auto", "lfs", "gpfs", "quota"}, Default: "auto",
+		Help: "quota command to use"},
+	{Pattern: "hpc.storage.user", Kind: KindString,
+		Help: "user whose quotas are queried (default $USER)"},
+	{Pattern: "slurm.accounts", Kind: KindStringList,
+		Help: "Slurm accounts to report (default: all of the user's associations)"},
+	{Pattern: "slurm.user", Kind: KindString,
+		Help: "user whose fairshare is reported (default $USER)"},
+	{Pattern: "slurm.period_start", Kind: KindDate,
+		Help: "start of the allocation period (default: first day of the month)"},
+	{Pattern: "pbs.projects", Kind: KindStringList,
+		Help: "PBS project codes (Account_Name) to report"},
+	{Pattern: "pbs.accounting_dir", Kind: KindString,
+		Help: "directory of PBS accounting logs named YYYYMMDD"},
+	{Pattern: "pbs.allocation_command", Kind: KindString,
+		Help: "site command printing '<project> <allocated> <used>' core-hour lines"},
+	{Pattern: "pbs.allocations.<project>", Kind: KindFloat,
+		Help: "core-hours allocated to a PBS project for the period"},
+	{Pattern: "pbs.period_start", Kind: KindDate,
+		Help: "start of the allocation period (default: first day of the month)"},
+	{Pattern: "pricing.*.price", Kind: KindFloat,
+		Help: "USD per unit beyond the entitlement (request, or 1M tokens)"},
+	{Pattern: "pricing.*.plans.<plan>", Kind: KindFloat,
+		Help: "USD per unit for one plan or model of the provider"},
+	{Pattern: "budget.monthly", Kind: KindFloat,
+		Help: "overall monthly budget in USD across all providers"},
+	{Pattern: "history.enabled", Kind: KindBool, Default: "true",
+		Help: "record a snapshot of every successful fetch for usage history"},
+	{Pattern: "history.retention", Kind: KindDuration, Default: "2160h",
+		Help: "how long snapshots are kept (2160h is 90 days)"},
+	{Pattern: "history.path", Kind: KindString,
+		Help: "snapshot database (default $XDG_DATA_HOME/qcli/history.db)"},
+	{Pattern: "cache.enabled", Kind: KindBool, Default: "true",
+		Help: "reuse recently fetched reports instead of calling provider APIs"},
+	{Pattern: "cache.ttl", Kind: KindDuration, Default: "1m",
+		Help: "how long a fetched report is reused"},
+	{Pattern: "cache.stale_on_error", Kind: KindBool, Default: "true",
+		Help: "show the last good report, marked stale, when a fetch fails"},
+	{Pattern: "cache.max_age", Kind: KindDuration, Default: "24h",
+		Help: "oldest report shown when a fetch fails; older ones are deleted"},
+	{Pattern: "cache.path", Kind: KindString,
+		Help: "cache directory (default $XDG_CACHE_HOME/qcli)"},
+	{Pattern: "calendar.timezone", Kind: KindTimezone,
+		Help: "IANA time zone for day boundaries, e.g. America/Bogota (default: local)"},
+	{Pattern: "calendar.workdays", Kind: KindStringList, Enum: []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"},
+		Help: "working days of the week (default: mon,tue,wed,thu,fri)"},
+	{Pattern: "calendar.holidays", Kind: KindString,
+		Help: "holiday file: .ics calendar or YAML list of dates"},
+}
+
+// ProviderIDs returns the configuration IDs of every supported provider.
+func ProviderIDs() []string {
+	return providers.IDs()
+}
+
+// CanonicalKey replaces a provider alias in the provider segment of key with
+// the provider's ID, e.g. providers.github-copilot.budget becomes
+// providers.copilot.budget. Other keys are returned unchanged.
+func CanonicalKey(key string) string {
+	segs := strings.Split(key, ".")
+	for _, k := range Schema {
+		psegs := strings.Split(k.Pattern, ".")
+		if len(psegs) != len(segs) {
+			continue
+		}
+		for j, ps := range psegs {
+			if ps == "*" {
+				if id, ok := providers.Resolve(segs[j]); ok {
+					segs[j] = id
+				}
+				break
+			}
+			if ps != segs[j] && !isPlaceholder(ps) {
+				break
+			}
+		}
+	}
+	return strings.Join(segs, ".")
+}
+
+// Lookup returns the schema entry for key, or an error explaining why the key
+// is not valid and what the closest valid key is. Provider aliases are
+// accepted wherever a provider ID is.
+func Lookup(key string) (*Key, error) {
+	segs := strings.Split(CanonicalKey(key), ".")
+	for i := range Schema {
+		k := &Schema[i]
+		psegs := strings.Split(k.Pattern, ".")
+		if len(psegs) != len(segs) {
+			continue
+		}
+		matched := true
+		for j, ps := range psegs {
+			if ps != "*" && !isPlaceholder(ps) && ps != segs[j] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		for j, ps := range psegs {
+			if ps == "*" && !contains(ProviderIDs(), segs[j]) {
+				return nil, fmt.Errorf("unknown provider %q in key %q; valid providers: %s",
+					segs[j], key, strings.Join(ProviderIDs(), ", "))
+			}
+		}
+		return k, nil
+	}
+
+	msg := fmt.Sprintf("unknown config key %q", key)
+	if s := suggest(key); s != "" {
+		msg += fmt.Sprintf("; did you mean %q?", s)
+	}
+	return nil, fmt.Errorf("%s (run 'qcli config --help' for the list of keys)", msg)
+}
+
+// Parse converts a command-line string into a typed value for this key.
+func (k *Key) Parse(raw string) (interface{}, error) {
+	switch k.Kind {
+	case KindBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expects true or false, got %q", raw)
+		}
+		return b, nil
+	case KindInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expects an integer, got %q", raw)
+		}
+		if n < k.Min || (k.Max > 0 && n > k.Max) {
+			return nil, fmt.Errorf("must be between %d and %d, got %d", k.Min, k.Max, n)
+		}
+		return n, nil
+	case KindFloat:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || f < 0 {
+			return nil, fmt.Errorf("expects a non-negative number, got %q", raw)
+		}
+		return f, nil
+	case KindDuration:
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("expects a positive duration such as 10s or 1m, got %q", raw)
+		}
+		return d.String(), nil
+	case KindStringList:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			if k.Enum != nil && !contains(k.Enum, item) {
+				return nil, fmt.Errorf("items must be one of %s, got %q", strings.Join(k.Enum, ", "), item)
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	case KindDate:
+		if _, err := time.Parse("2006-01-02", raw); err != nil {
+			return nil, fmt.Errorf("expects a date as YYYY-MM-DD, got %q", raw)
+		}
+		return raw, nil
+	case KindTimezone:
+		if _, err := time.LoadLocation(raw); err != nil {
+			return nil, fmt.Errorf("expects an IANA time zone such as Europe/Berlin, got %q", raw)
+		}
+		return raw, nil
+	case KindURL:
+		schemes := k.Enum
+		if schemes == nil {
+			schemes = []string{"http", "https"}
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" || !contains(schemes, u.Scheme) && !(k.LoopbackHTTP && u.Scheme == "http" && isLoopback(u.Hostname())) {
+			allowed := strings.Join(schemes, " or ")
+			if k.LoopbackHTTP {
+				allowed += " (or localhost http)"
+			}
+			return nil, fmt.Errorf("expects an %s URL such as https://ghe.example.com/api/v3, got %q", allowed, raw)
+		}
+		return strings.TrimSuffix(raw, "/"), nil
+	case KindEnum:
+		if !contains(k.Enum, raw) {
+			return nil, fmt.Errorf("must be one of %s, got %q", strings.Join(k.Enum, ", "), raw)
+		}
+		return raw, nil
+	default:
+		return raw, nil
+	}
+}
+
+// Describe renders the schema as help text, one key per line.
+func Describe() string {
+	var b strings.Builder
+	for _, k := range Schema {
+		typ := k.Kind.String()
+		if k.Kind == KindEnum {
+			typ = strings.Join(k.Enum, "|")
+		}
+		line := fmt.Sprintf("  %-28s %-10s %s", k.Pattern, typ, k.Help)
+		if k.Default != "" {
+			line += fmt.Sprintf(" (default %s)", k.Default)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// suggest returns the schema key closest to key, if any is reasonably close.
+func suggest(key string) string {
+	segs := strings.Split(key, ".")
+	best, bestDist := "", 4
+	for _, k := range Schema {
+		psegs := strings.Split(k.Pattern, ".")
+		if len(psegs) != len(segs) {
+			continue
+		}
+		// Substitute the user's provider segment so the suggestion is concrete.
+		cand := make([]string, len(psegs))
+		for i, ps := range psegs {
+			cand[i] = ps
+			if ps == "*" || isPlaceholder(ps) {
+				cand[i] = segs[i]
+			}
+		}
+		c := strings.Join(cand, ".")
+		if d := levenshtein(key, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// isPlaceholder reports whether a pattern segment such as "<plan>" accepts any name.
+func isPlaceholder(seg string) bool {
+	return strings.HasPrefix(seg, "<") && strings.HasSuffix(seg, ">")
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// isLoopback reports whether host is localhost or a loopback address.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		key     Key
+		raw     string
+		want    interface{}
+		wantErr bool
+	}{
+		{Key{Kind: KindString}, "anything", "anything", false},
+		{Key{Kind: KindBool}, "true", true, false},
+		{Key{Kind: KindBool}, "yes", nil, true},
+		{Key{Kind: KindInt, Max: 100}, "80", 80, false},
+		{Key{Kind: KindInt, Max: 100}, "101", nil, true},
+		{Key{Kind: KindInt, Max: 100}, "-1", nil, true},
+		{Key{Kind: KindInt}, "ten", nil, true},
+		{Key{Kind: KindFloat}, "12.5", 12.5, false},
+		{Key{Kind: KindFloat}, "-3", nil, true},
+		{Key{Kind: KindDuration}, "90s", "1m30s", false},
+		{Key{Kind: KindDuration}, "0s", nil, true},
+		{Key{Kind: KindDuration}, "soon", nil, true},
+		{Key{Kind: KindEnum, Enum: []string{"table", "json"}}, "json", "json", false},
+		{Key{Kind: KindEnum, Enum: []string{"table", "json"}}, "yaml", nil, true},
+		{Key{Kind: KindStringList}, " /scratch, ,/home ", []string{"/scratch", "/home"}, false},
+		{Key{Kind: KindStringList, Enum: []string{"mon", "tue"}}, "mon,tue", []string{"mon", "tue"}, false},
+		{Key{Kind: KindStringList, Enum: []string{"mon", "tue"}}, "mon,sun", nil, true},
+		{Key{Kind: KindDate}, "2026-10-01", "2026-10-01", false},
+		{Key{Kind: KindDate}, "01/10/2026", nil, true},
+		{Key{Kind: KindTimezone}, "America/Bogota", "America/Bogota", false},
+		{Key{Kind: KindTimezone}, "Mars/Olympus", nil, true},
+		{Key{Kind: KindURL}, "https://ghe.example.com/api/v3/", "https://ghe.example.com/api/v3", false},
+		{Key{Kind: KindURL}, "http://localhost:8080", "http://localhost:8080", false},
+		{Key{Kind: KindURL}, "ftp://example.com", nil, true},
+		{Key{Kind: KindURL}, "https://", nil, true},
+		{Key{Kind: KindURL, Enum: []string{"https"}}, "https://monitoring.example.com", "https://monitoring.example.com", false},
+		{Key{Kind: KindURL, Enum: []string{"https"}}, "http://localhost:8080", nil, true},
+		{Key{Kind: KindURL, Enum: []string{"https"}, LoopbackHTTP: true}, "http://localhost:8080/token", "http://localhost:8080/token", false},
+		{Key{Kind: KindURL, Enum: []string{"https"}, LoopbackHTTP: true}, "http://[::1]:8080/token", "http://[::1]:8080/token", false},
+		{Key{Kind: KindURL, Enum: []string{"https"}, LoopbackHTTP: true}, "http://oauth.example.com/token", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.key.Parse(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s Parse(%q) error = %v, wantErr %v", tt.key.Kind, tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s Parse(%q) = %#v, want %#v", tt.key.Kind, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		key     string
+		pattern string
+		errHas  string
+	}{
+		{key: "threshold", pattern: "threshold"},
+		{key: "providers.claude.threshold", pattern: "providers.*.threshold"},
+		{key: "providers.copilot.base_url", pattern: "providers.*.base_url"},
+		{key: "providers.copilot.budget", pattern: "providers.*.budget"},
+		{key: "providers.vertex.budget", pattern: "providers.*.budget"},
+		{key: "providers.vertex.base_url", pattern: "providers.vertex.base_url"},
+		// Aliases validate like the IDs they stand for.
+		{key: "providers.github-copilot.base_url", pattern: "providers.*.base_url"},
+		{key: "providers.vertex-ai.base_url", pattern: "providers.vertex.base_url"},
+		{key: "providers.antigravity.token_url", pattern: "providers.antigravity.token_url"},
+		{key: "providers.claude.token_url", errHas: "unknown config key"},
+		{key: "pricing.copilot.plans.business", pattern: "pricing.*.plans.<plan>"},
+		{key: "pricing.github-copilot.plans.business", pattern: "pricing.*.plans.<plan>"},
+		{key: "pricing.claude.plans", errHas: "unknown config key"},
+		{key: "providers.nope.threshold", errHas: `unknown provider "nope"`},
+		{key: "pricing.nope.plans.business", errHas: `unknown provider "nope"`},
+		{key: "treshold", errHas: `did you mean "threshold"`},
+		{key: "providers.claude.treshold", errHas: `did you mean "providers.claude.threshold"`},
+	}
+	for _, tt := range tests {
+		k, err := Lookup(tt.key)
+		if tt.errHas != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errHas) {
+				t.Errorf("Lookup(%q) error = %v, want it to mention %q", tt.key, err, tt.errHas)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", tt.key, err)
+			continue
+		}
+		if k.Pattern != tt.pattern {
+			t.Errorf("Lookup(%q) matched %q, want %q", tt.key, k.Pattern, tt.pattern)
+		}
+	}
+}
+
+func TestCanonicalKey(t *testing.T) {
+	tests := map[string]string{
+		"providers.github-copilot.budget": "providers.copilot.budget",
+		"providers.vertex-ai.base_url":    "providers.vertex.base_url",
+		"pricing.gemini-cli.plans.pro":    "pricing.gemini.plans.pro",
+		"providers.copilot.budget":        "providers.copilot.budget",
+		"hpc.storage.paths":               "hpc.storage.paths",
+		"threshold":                       "threshold",
+	}
+	for key, want := range tests {
+		if got := CanonicalKey(key); got != want {
+			t.Errorf("CanonicalKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/history"
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+	"github.com/JValdivia23/quota-cli/pkg/pricing"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+	"github.com/spf13/viper"
+)
+
+// The accessors below read the merged configuration (file + QCLI_* env vars)
+// that cmd/quota loads into viper at startup, falling back to schema defaults.
+
+// Output returns the default output format ("table" or "json").
+func Output() string {
+	if viper.IsSet("output") {
+		return viper.GetString("output")
+	}
+	return "table"
+}
+
+// Timeout returns the default per-provider fetch timeout.
+func Timeout() time.Duration {
+	if d := viper.GetDuration("timeout"); d > 0 {
+		return d
+	}
+	return 15 * time.Second
+}
+
+// Threshold returns the global usage warning threshold in percent.
+func Threshold() int {
+	if viper.IsSet("threshold") {
+		return viper.GetInt("threshold")
+	}
+	return 80
+}
+
+// providerKey returns the "<section>.<id>.<field>" key, or the same key with
+// one of the provider's aliases in place of id if only that one is set, so
+// hand-written keys such as providers.github-copilot.budget keep working.
+func providerKey(section, id, field string) string {
+	key := section + "." + id + "." + field
+	if viper.IsSet(key) {
+		return key
+	}
+	for _, alias := range providers.Keys(id)[1:] {
+		if k := section + "." + alias + "." + field; viper.IsSet(k) {
+			return k
+		}
+	}
+	return key
+}
+
+// ProviderEnabled reports whether the provider may be queried (default true).
+func ProviderEnabled(id string) bool {
+	key := providerKey("providers", id, "enabled")
+	if viper.IsSet(key) {
+		return viper.GetBool(key)
+	}
+	return true
+}
+
+// ProviderName returns the configured display name override, or "".
+func ProviderName(id string) string {
+	return viper.GetString(providerKey("providers", id, "name"))
+}
+
+// ProviderTimeout returns the fetch timeout for a provider, falling back to Timeout.
+func ProviderTimeout(id string) time.Duration {
+	if d := viper.GetDuration(providerKey("providers", id, "timeout")); d > 0 {
+		return d
+	}
+	return Timeout()
+}
+
+// ProviderThreshold returns the warning threshold for a provider, falling back to Threshold.
+func ProviderThreshold(id string) int {
+	key := providerKey("providers", id, "threshold")
+	if viper.IsSet(key) {
+		return viper.GetInt(key)
+	}
+	return Threshold()
+}
+
+// ProviderForecastModel returns the forecasting model configured for a provider.
+func ProviderForecastModel(id string) string {
+	if m := viper.GetString(providerKey("providers", id, "forecast_model")); m != "" {
+		return m
+	}
+	return predictor.DefaultModel
+}
+
+// ProviderBudget returns a provider's monthly budget in USD, or 0 when unset.
+func ProviderBudget(id string) float64 {
+	return viper.GetFloat64(providerKey("providers", id, "budget"))
+}
+
+// MonthlyBudget returns the overall monthly budget in USD, or 0 when unset.
+func MonthlyBudget() float64 {
+	return viper.GetFloat64("budget.monthly")
+}
+
+// HistoryEnabled reports whether fetched reports are recorded as snapshots (default true).
+func HistoryEnabled() bool {
+	if viper.IsSet("history.enabled") {
+		return viper.GetBool("history.enabled")
+	}
+	return true
+}
+
+// HistoryRetention returns how long snapshots are kept (default 90 days).
+func HistoryRetention() time.Duration {
+	if d := viper.GetDuration("history.retention"); d > 0 {
+		return d
+	}
+	return history.DefaultRetention
+}
+
+// HistoryPath returns the configured snapshot database path, or "" for the default.
+func HistoryPath() string {
+	return viper.GetString("history.path")
+}
+
+// CacheEnabled reports whether fetched reports are cached (default true).
+func CacheEnabled() bool {
+	if viper.IsSet("cache.enabled") {
+		return viper.GetBool("cache.enabled")
+	}
+	return true
+}
+
+// CachePath returns the configured cache directory, or "" for the default.
+func CachePath() string {
+	return viper.GetString("cache.path")
+}
+
+// CacheTTL returns how long a provider's report is reused, falling back to
+// cache.ttl and then one minute.
+func CacheTTL(id string) time.Duration {
+	if d := viper.GetDuration(providerKey("providers", id, "cache_ttl")); d > 0 {
+		return d
+	}
+	if d := viper.GetDuration("cache.ttl"); d > 0 {
+		return d
+	}
+	return time.Minute
+}
+
+// CacheStaleOnError reports whether a cached report is shown when a fetch
+// fails (default true).
+func CacheStaleOnError() bool {
+	if viper.IsSet("cache.stale_on_error") {
+		return viper.GetBool("cache.stale_on_error")
+	}
+	return true
+}
+
+// CacheMaxAge returns the age beyond which a cached report is no longer shown
+// in place of a failed fetch, and is deleted from the cache.
+func CacheMaxAge() time.Duration {
+	if d := viper.GetDuration("cache.max_age"); d > 0 {
+		return d
+	}
+	return 24 * time.Hour
+}
+
+// Pricing returns the built-in price table with any pricing.* overrides applied.
+func Pricing() *pricing.Table {
+	table := pricing.Defaults()
+	for _, id := range ProviderIDs() {
+		key := providerKey("pricing", id, "price")
+		if viper.IsSet(key) {
+			table.Override(id, "", viper.GetFloat64(key), "config: "+key)
+		}
+		// Plans under an alias first, so those under the ID win.
+		keys := providers.Keys(id)
+		for i := len(keys) - 1; i >= 0; i-- {
+			for plan := range viper.GetStringMap("pricing." + keys[i] + ".plans") {
+				key := "pricing." + keys[i] + ".plans." + plan
+				table.Override(id, plan, viper.GetFloat64(key), "config: "+key)
+			}
+		}
+	}
+	return table
+}
+
+// Location returns the configured calendar.timezone, or time.Local when it is
+// unset or invalid.
+func Location() *time.Location {
+	if tz := viper.GetString("calendar.timezone"); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// Calendar returns the time zone, working week and holidays used to bucket and
+// forecast daily usage. On error the zero Calendar (local time, Monday–Friday,
+// no holidays) is returned together with the error.
+func Calendar() (predictor.Calendar, error) {
+	var cal predictor.Calendar
+	if tz := viper.GetString("calendar.timezone"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return predictor.Calendar{}, fmt.Errorf("calendar.timezone: %w", err)
+		}
+		cal.Location = loc
+	}
+	if names := viper.GetStringSlice("calendar.workdays"); len(names) > 0 {
+		days, err := predictor.ParseWeekdays(names)
+		if err != nil {
+			return predictor.Calendar{}, fmt.Errorf("calendar.workdays: %w", err)
+		}
+		cal.WorkDays = days
+	}
+	if path := viper.GetString("calendar.holidays"); path != "" {
+		holidays, err := predictor.LoadHolidays(path)
+		if err != nil {
+			return predictor.Calendar{}, fmt.Errorf("calendar.holidays: %w", err)
+		}
+		cal.Holidays = holidays
+	}
+	return cal, nil
+}
+
+// HTTP returns the settings of the client providers call their APIs with.
+// Unset values are left at zero so providers apply their defaults.
+func HTTP() providers.HTTPConfig {
+	c := providers.HTTPConfig{
+		ConnectTimeout: viper.GetDuration("http.connect_timeout"),
+		Timeout:        viper.GetDuration("http.timeout"),
+		CABundle:       viper.GetString("http.ca_bundle"),
+	}
+	if viper.IsSet("http.retries") {
+		// 0 in HTTPConfig means the default, so disable retries explicitly.
+		if c.Retries = viper.GetInt("http.retries"); c.Retries == 0 {
+			c.Retries = -1
+		}
+	}
+	return c
+}
+
+// ProviderSettings collects the configuration providers need beyond credentials.
+func ProviderSettings() providers.Settings {
+	baseURLs := make(map[string]string)
+	tokenURLs := make(map[string]string)
+	for _, id := range ProviderIDs() {
+		if u := viper.GetString(providerKey("providers", id, "base_url")); u != "" {
+			baseURLs[id] = u
+		}
+		if u := viper.GetString(providerKey("providers", id, "token_url")); u != "" {
+			tokenURLs[id] = u
+		}
+	}
+	return providers.Settings{
+		Location:  Location(),
+		BaseURLs:  baseURLs,
+		TokenURLs: tokenURLs,
+		Storage: hpc.StorageConfig{
+			Paths:    viper.GetStringSlice("hpc.storage.paths"),
+			Projects: viper.GetStringSlice("hpc.storage.projects"),
+			Tool:     viper.GetString("hpc.storage.tool"),
+			User:     viper.GetString("hpc.storage.user"),
+		},
+		Slurm: hpc.SlurmConfig{
+			Accounts:    viper.GetStringSlice("slurm.accounts"),
+			User:        viper.GetString("slurm.user"),
+			PeriodStart: dateSetting("slurm.period_start"),
+		},
+		PBS: hpc.PBSConfig{
+			Projects:          viper.GetStringSlice("pbs.projects"),
+			AccountingDir:     viper.GetString("pbs.accounting_dir"),
+			AllocationCommand: viper.GetString("pbs.allocation_command"),
+			Allocations:       pbsAllocations(),
+			PeriodStart:       dateSetting("pbs.period_start"),
+		},
+	}
+}
+
+// pbsAllocations returns the pbs.allocations.<project> core-hours, keyed by
+// the lowercased project code viper stores them under.
+func pbsAllocations() map[string]float64 {
+	allocs := make(map[string]float64)
+	for project := range viper.GetStringMap("pbs.allocations") {
+		allocs[project] = viper.GetFloat64("pbs.allocations." + project)
+	}
+	return allocs
+}
+
+// dateSetting parses a YYYY-MM-DD key in local time, returning the zero time when unset or invalid.
+func dateSetting(key string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", viper.GetString(key), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestProviderSettingsFollowAliases(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("providers.github-copilot.budget", 10)
+	viper.Set("providers.vertex-ai.base_url", "https://monitoring.example.com")
+	viper.Set("providers.openai.budget", 20)
+
+	if got := ProviderBudget("copilot"); got != 10 {
+		t.Errorf("ProviderBudget(copilot) = %v, want the aliased value 10", got)
+	}
+	if got := ProviderSettings().BaseURLs["vertex"]; got != "https://monitoring.example.com" {
+		t.Errorf("vertex base URL = %q", got)
+	}
+
+	// The ID wins over an alias.
+	viper.Set("providers.copilot.budget", 5)
+	if got := ProviderBudget("copilot"); got != 5 {
+		t.Errorf("ProviderBudget(copilot) = %v, want 5", got)
+	}
+	if got := ProviderBudget("openai"); got != 20 {
+		t.Errorf("ProviderBudget(openai) = %v, want 20", got)
+	}
+}
@@ -2,6 +2,14 @@ package main
 
 import "github.com/JValdivia23/quota-cli/cmd/quota"
 
+// Set by GoReleaser with -ldflags "-X main.version=...".
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
 func main() {
+	quota.SetVersion(version, commit, date)
 	quota.Execute()
 }
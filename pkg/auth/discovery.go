@@ -20,6 +20,7 @@ import (
 func DiscoverOpenCodeAuth() (*models.OpenCodeAuthConfig, error) {
 	cfg := &models.OpenCodeAuthConfig{
 		RawKeys: make(map[string]interface{}),
+		Sources: make(map[string]string),
 	}
 
 	// 1. Load auth.json
@@ -31,6 +32,7 @@ func DiscoverOpenCodeAuth() (*models.OpenCodeAuthConfig, error) {
 				// Merge all keys
 				for k, v := range raw {
 					cfg.RawKeys[k] = v
+					cfg.Sources[k] = "auth.json: " + p
 				}
 				// Also populate typed fields via struct decode
 				json.Unmarshal(data, cfg) //nolint:errcheck
@@ -46,6 +48,7 @@ func DiscoverOpenCodeAuth() (*models.OpenCodeAuthConfig, error) {
 			var antRaw map[string]interface{}
 			if err := json.Unmarshal(data, &antRaw); err == nil {
 				cfg.RawKeys["antigravity"] = antRaw
+				cfg.Sources["antigravity"] = "antigravity-accounts.json: " + p
 				break
 			}
 		}
@@ -55,8 +58,9 @@ func DiscoverOpenCodeAuth() (*models.OpenCodeAuthConfig, error) {
 	injectEnvKeys(cfg)
 
 	// 4. Load opencode DB for OpenCode Zen token
-	if token := discoverZenDBToken(); token != "" {
+	if token, dbPath := discoverZenDBToken(); token != "" {
 		cfg.RawKeys["opencode-zen-token"] = token
+		cfg.Sources["opencode-zen-token"] = "opencode.db: " + dbPath
 	}
 
 	if !loaded && len(cfg.RawKeys) == 0 {
@@ -69,43 +73,55 @@ func DiscoverOpenCodeAuth() (*models.OpenCodeAuthConfig, error) {
 // injectEnvKeys reads standard environment variables and injects them into RawKeys
 // so they work exactly like auth.json entries without any OpenCode dependency.
 func injectEnvKeys(cfg *models.OpenCodeAuthConfig) {
-	envMap := map[string]string{
-		"OPENAI_API_KEY":     "openai.key",
-		"ANTHROPIC_API_KEY":  "anthropic.key",
-		"OPENROUTER_API_KEY": "openrouter.key",
-		"GEMINI_API_KEY":     "gemini.key",
-		"GOOGLE_API_KEY":     "google.key",
-		"GITHUB_TOKEN":       "github-copilot.access",
-		"COPILOT_TOKEN":      "github-copilot.access",
+	// COPILOT_TOKEN comes before GITHUB_TOKEN so the more specific variable wins.
+	envMap := []struct{ envVar, keyPath string }{
+		{"OPENAI_API_KEY", "openai.key"},
+		{"ANTHROPIC_API_KEY", "claude.key"},
+		{"OPENROUTER_API_KEY", "openrouter.key"},
+		{"GEMINI_API_KEY", "gemini.key"},
+		{"GOOGLE_API_KEY", "google.key"},
+		{"COPILOT_TOKEN", "github-copilot.access"},
+		{"GITHUB_TOKEN", "github-copilot.access"},
 	}
 
-	for envVar, keyPath := range envMap {
-		if val := os.Getenv(envVar); val != "" {
+	for _, e := range envMap {
+		if val := os.Getenv(e.envVar); val != "" {
 			// Only inject if not already set by auth.json
-			if _, exists := cfg.RawKeys[keyPath]; !exists {
-				cfg.RawKeys[keyPath] = val
+			if _, exists := cfg.RawKeys[e.keyPath]; !exists {
+				cfg.RawKeys[e.keyPath] = val
+				cfg.Sources[e.keyPath] = "env: " + e.envVar
 			}
 		}
 	}
 
-	// Also check OPENAI_API_KEY → nested openai.access (the format auth.json uses)
-	if key := os.Getenv("OPENAI_API_KEY"); key != "" {
-		if _, ok := cfg.RawKeys["openai"]; !ok {
-			cfg.RawKeys["openai"] = map[string]interface{}{"access": key}
-		}
+	// Also mirror env keys into the nested format auth.json uses, which is
+	// what the providers actually read (e.g. openai.access, github-copilot.access).
+	// GITHUB_TOKEN is deliberately left out: gh and CI tokens are not
+	// Copilot credentials, so only COPILOT_TOKEN turns Copilot on.
+	// ANTHROPIC_API_KEY goes to claude, not anthropic: the anthropic entry
+	// is opencode's OAuth login, which belongs to Antigravity.
+	nestedEnv := []struct{ envVar, provider, field string }{
+		{"OPENAI_API_KEY", "openai", "access"},
+		{"ANTHROPIC_API_KEY", "claude", "access"},
+		{"COPILOT_TOKEN", "github-copilot", "access"},
+		{"GOOGLE_API_KEY", "google", "key"},
 	}
-	if key := os.Getenv("ANTHROPIC_API_KEY"); key != "" {
-		if _, ok := cfg.RawKeys["anthropic"]; !ok {
-			cfg.RawKeys["anthropic"] = map[string]interface{}{"access": key}
+	for _, e := range nestedEnv {
+		if key := os.Getenv(e.envVar); key != "" {
+			if _, ok := cfg.RawKeys[e.provider]; !ok {
+				cfg.RawKeys[e.provider] = map[string]interface{}{e.field: key}
+				cfg.Sources[e.provider] = "env: " + e.envVar
+			}
 		}
 	}
 }
 
 // discoverZenDBToken looks for an active control_account token in the opencode SQLite DB.
-func discoverZenDBToken() string {
+// It returns the token and the path of the database it was read from.
+func discoverZenDBToken() (string, string) {
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return ""
+		return "", ""
 	}
 	dbPaths := []string{
 		filepath.Join(home, ".local", "share", "opencode", "opencode.db"),
@@ -127,12 +143,12 @@ func discoverZenDBToken() string {
 		} {
 			if err := db.QueryRow(query).Scan(&token); err == nil && token != "" {
 				db.Close()
-				return token
+				return token, dbPath
 			}
 		}
 		db.Close()
 	}
-	return ""
+	return "", ""
 }
 
 func getAuthJSONPaths() []string {
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func newConfig() *models.OpenCodeAuthConfig {
+	return &models.OpenCodeAuthConfig{
+		RawKeys: make(map[string]interface{}),
+		Sources: make(map[string]string),
+	}
+}
+
+func clearEnv(t *testing.T) {
+	for _, v := range []string{"OPENAI_API_KEY", "ANTHROPIC_API_KEY", "OPENROUTER_API_KEY",
+		"GEMINI_API_KEY", "GOOGLE_API_KEY", "GITHUB_TOKEN", "COPILOT_TOKEN"} {
+		t.Setenv(v, "")
+	}
+}
+
+func TestGitHubTokenDoesNotEnableCopilot(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("GITHUB_TOKEN", "ghp_ci")
+
+	cfg := newConfig()
+	injectEnvKeys(cfg)
+	if got := cfg.GetNestedField("github-copilot", "access"); got != "" {
+		t.Errorf("GITHUB_TOKEN was mapped to github-copilot.access: %q", got)
+	}
+}
+
+func TestCopilotTokenWinsOverGitHubToken(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("GITHUB_TOKEN", "ghp_ci")
+	t.Setenv("COPILOT_TOKEN", "gho_copilot")
+
+	cfg := newConfig()
+	injectEnvKeys(cfg)
+	if got := cfg.GetNestedField("github-copilot", "access"); got != "gho_copilot" {
+		t.Errorf("github-copilot.access = %q, want the COPILOT_TOKEN value", got)
+	}
+	if got := cfg.RawKeys["github-copilot.access"]; got != "gho_copilot" {
+		t.Errorf("flat github-copilot.access = %v, want the COPILOT_TOKEN value", got)
+	}
+	if src := cfg.Source("github-copilot"); src != "env: COPILOT_TOKEN" {
+		t.Errorf("source = %q", src)
+	}
+}
+
+func TestEnvKeysDoNotOverrideAuthJSON(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+
+	cfg := newConfig()
+	cfg.RawKeys["openai"] = map[string]interface{}{"access": "sk-file"}
+	cfg.Sources["openai"] = "auth.json: /tmp/auth.json"
+	injectEnvKeys(cfg)
+	if got := cfg.GetNestedField("openai", "access"); got != "sk-file" {
+		t.Errorf("openai.access = %q, want the auth.json value", got)
+	}
+	if got := cfg.RawKeys["openai.key"]; got != "sk-env" {
+		t.Errorf("openai.key = %v, want the env value", got)
+	}
+	if src := cfg.Source("openai.key"); src != "env: OPENAI_API_KEY" {
+		t.Errorf("source = %q", src)
+	}
+}
@@ -0,0 +1,221 @@
+// Package cache keeps the last good report of every provider on disk, so that
+// frequent invocations such as shell prompts and status bars do not hit the
+// provider APIs each time, and a failing fetch can fall back to older data.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+)
+
+// Store is a directory of cached reports, one JSON file per provider account.
+type Store struct {
+	dir string
+}
+
+// Entry is a cached report and the time it was fetched.
+type Entry struct {
+	FetchedAt time.Time              `json:"fetchedAt"`
+	Report    *models.ProviderReport `json:"report"`
+}
+
+// DefaultDir returns $XDG_CACHE_HOME/qcli, defaulting to ~/.cache/qcli.
+func DefaultDir() (string, error) {
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheHome = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheHome, "qcli"), nil
+}
+
+// Open opens (and if needed creates) the cache directory and deletes entries
+// fetched more than maxAge ago; 0 keeps them forever. Cached reports may
+// contain account details, so it is private to the user.
+func Open(dir string, maxAge time.Duration) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir}
+	if maxAge > 0 {
+		// A failed prune only leaves old files behind.
+		_ = s.Prune(time.Now().Add(-maxAge))
+	}
+	return s, nil
+}
+
+// Prune deletes every entry fetched before the given time, unreadable
+// entries, and temporary files that an interrupted Put left before it.
+func (s *Store) Prune(before time.Time) error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, f := range files {
+		name := f.Name()
+		var old bool
+		switch {
+		case strings.HasSuffix(name, ".tmp"):
+			info, err := f.Info()
+			old = err == nil && info.ModTime().Before(before)
+		case strings.HasSuffix(name, ".json"):
+			e, ok := s.Get(strings.TrimSuffix(name, ".json"))
+			old = !ok || e.FetchedAt.Before(before)
+		}
+		if old {
+			if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Key identifies the cache entry of one provider account, given the
+// provider's ID. account is any string that tells accounts apart, such as
+// where the credential was found.
+func Key(id, account string) string {
+	sum := sha256.Sum256([]byte(id + "\x00" + account))
+	return id + "-" + hex.EncodeToString(sum[:8])
+}
+
+// Get returns the entry stored under key, if there is a readable one.
+func (s *Store) Get(key string) (Entry, bool) {
+	b, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return Entry{}, false
+	}
+	var e Entry
+	if err := json.Unmarshal(b, &e); err != nil || e.Report == nil {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Put stores e under key. The file is replaced atomically so that concurrent
+// qcli processes never read a partial entry.
+func (s *Store) Put(key string, e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+// Policy controls how Wrap uses the cache.
+type Policy struct {
+	// TTL is how long a fetched report is served without fetching again;
+	// zero always fetches.
+	TTL time.Duration
+	// Refresh fetches even when a fresh entry exists (the result is still stored).
+	Refresh bool
+	// StaleOnError serves the last good report when a fetch fails, as long as
+	// it is younger than MaxAge (zero means any age).
+	StaleOnError bool
+	MaxAge       time.Duration
+}
+
+// Wrap returns p with its Fetch served from store according to policy. The
+// other methods are p's own.
+func Wrap(p providers.Provider, store *Store, policy Policy) providers.Provider {
+	return &cachedProvider{Provider: p, store: store, policy: policy}
+}
+
+type cachedProvider struct {
+	providers.Provider
+	store  *Store
+	policy Policy
+	// now is time.Now unless a test replaces it.
+	now func() time.Time
+}
+
+func (c *cachedProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	key := c.key(cfg)
+	entry, found := c.store.Get(key)
+	age := now.Sub(entry.FetchedAt)
+
+	if found && !c.policy.Refresh && age < c.policy.TTL {
+		return cached(entry, ""), nil
+	}
+
+	rep, err := c.Provider.Fetch(ctx, cfg)
+	if err == nil && rep != nil {
+		// A failed write only costs a fetch next time.
+		_ = c.store.Put(key, Entry{FetchedAt: now, Report: rep})
+		return rep, nil
+	}
+
+	if found && c.policy.StaleOnError && (c.policy.MaxAge <= 0 || age <= c.policy.MaxAge) {
+		reason := "no data returned"
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			reason = "timed out"
+		case err != nil:
+			reason = err.Error()
+		}
+		return cached(entry, reason), nil
+	}
+	return rep, err
+}
+
+// key tells the provider's accounts apart by the credentials it detects. The
+// masked Credential can match for two different secrets, so the Fingerprint
+// of the unmasked one is used when the provider sets it.
+func (c *cachedProvider) key(cfg *models.OpenCodeAuthConfig) string {
+	res, err := c.Detect(cfg)
+	if err != nil {
+		return Key(c.ID(), "")
+	}
+	id := res.Credential
+	if res.Fingerprint != "" {
+		id = res.Fingerprint
+	}
+	return Key(c.ID(), fmt.Sprintf("%s\x00%s", res.Source, id))
+}
+
+// cached marks the entry's report as served from the cache, and as stale when
+// the live fetch failed with reason.
+func cached(e Entry, reason string) *models.ProviderReport {
+	rep := e.Report
+	fetchedAt := e.FetchedAt
+	rep.CachedAt = &fetchedAt
+	rep.Stale = reason != ""
+	rep.StaleReason = reason
+	return rep
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+)
+
+// fakeProvider counts fetches and fails while err is set.
+type fakeProvider struct {
+	calls     int
+	remaining int
+	err       error
+	account   string
+	secret    string
+}
+
+func (f *fakeProvider) Name() string              { return "Fake AI" }
+func (f *fakeProvider) ID() string                { return "fake" }
+func (f *fakeProvider) Type() models.ProviderType { return models.TypeQuotaBased }
+func (f *fakeProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.ProviderReport{Name: f.Name(), Type: f.Type(), Remaining: f.remaining}, nil
+}
+func (f *fakeProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
+	return nil, nil
+}
+func (f *fakeProvider) Detect(cfg *models.OpenCodeAuthConfig) (providers.DetectResult, error) {
+	res := providers.DetectResult{Available: true, Credential: f.account}
+	if f.secret != "" {
+		res.Fingerprint = providers.Fingerprint(f.secret)
+	}
+	return res, nil
+}
+func (f *fakeProvider) Capabilities() providers.Capabilities { return providers.Capabilities{} }
+
+func TestWrap(t *testing.T) {
+	store, err := Open(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	fake := &fakeProvider{remaining: 40, account: "alice"}
+	wrap := func(policy Policy) *cachedProvider {
+		c := Wrap(fake, store, policy).(*cachedProvider)
+		c.now = func() time.Time { return now }
+		return c
+	}
+	fetch := func(c *cachedProvider) *models.ProviderReport {
+		t.Helper()
+		rep, err := c.Fetch(context.Background(), &models.OpenCodeAuthConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rep
+	}
+	policy := Policy{TTL: time.Minute, StaleOnError: true, MaxAge: time.Hour}
+
+	if rep := fetch(wrap(policy)); rep.CachedAt != nil || fake.calls != 1 {
+		t.Fatalf("first fetch: cachedAt %v after %d calls", rep.CachedAt, fake.calls)
+	}
+
+	// Within the TTL the cached report is served.
+	now = now.Add(30 * time.Second)
+	fake.remaining = 30
+	if rep := fetch(wrap(policy)); rep.CachedAt == nil || rep.Remaining != 40 || rep.Stale || fake.calls != 1 {
+		t.Errorf("fresh hit: %+v after %d calls", rep, fake.calls)
+	}
+
+	// --refresh fetches anyway and updates the cache.
+	refresh := policy
+	refresh.Refresh = true
+	if rep := fetch(wrap(refresh)); rep.CachedAt != nil || rep.Remaining != 30 || fake.calls != 2 {
+		t.Errorf("refresh: %+v after %d calls", rep, fake.calls)
+	}
+
+	// Another account does not see this one's report.
+	fake.account = "bob"
+	if rep := fetch(wrap(policy)); rep.CachedAt != nil || fake.calls != 3 {
+		t.Errorf("other account served from cache: %+v", rep)
+	}
+	fake.account = "alice"
+
+	// Nor does another secret that masks to the same credential.
+	fake.secret = "sk-alice-rotated"
+	if rep := fetch(wrap(policy)); rep.CachedAt != nil || fake.calls != 4 {
+		t.Errorf("same masked credential served from cache: %+v", rep)
+	}
+	fake.secret = ""
+
+	// After the TTL a failing fetch falls back to the stale report.
+	now = now.Add(10 * time.Minute)
+	fake.err = errors.New("status 429")
+	rep := fetch(wrap(policy))
+	if !rep.Stale || rep.StaleReason != "status 429" || rep.Remaining != 30 || fake.calls != 5 {
+		t.Errorf("stale fallback: %+v after %d calls", rep, fake.calls)
+	}
+
+	// Beyond MaxAge the error is returned.
+	now = now.Add(2 * time.Hour)
+	if _, err := wrap(policy).Fetch(context.Background(), &models.OpenCodeAuthConfig{}); err == nil {
+		t.Error("report older than MaxAge was served")
+	}
+}
+
+func TestOpenPrunesOldEntries(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := &models.ProviderReport{Name: "Fake AI"}
+	now := time.Now()
+	store.Put("old", Entry{FetchedAt: now.Add(-48 * time.Hour), Report: report})
+	store.Put("new", Entry{FetchedAt: now.Add(-time.Hour), Report: report})
+
+	store, err = Open(dir, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Get("old"); ok {
+		t.Error("entry older than maxAge was kept")
+	}
+	if _, ok := store.Get("new"); !ok {
+		t.Error("entry younger than maxAge was pruned")
+	}
+}
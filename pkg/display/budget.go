@@ -0,0 +1,74 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// BudgetRow is one provider's spend this month against its budget.
+type BudgetRow struct {
+	Provider string              `json:"provider"`
+	Budget   models.BudgetStatus `json:"budget"`
+}
+
+// PrintBudget renders each provider's spend, budget and month-end projection,
+// followed by their total against the overall budget.
+func PrintBudget(rows []BudgetRow, total models.BudgetStatus) {
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Provider < rows[j].Provider })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Provider\tSpent\tBudget\tLeft\tProjected\tStatus")
+	for _, r := range rows {
+		printBudgetRow(w, r.Provider, r.Budget)
+	}
+	printBudgetRow(w, "Total", total)
+	w.Flush()
+
+	if len(rows) == 0 {
+		fmt.Println("\nNo provider reports spend; set prices with 'qcli config set pricing.<id>.price'.")
+	}
+}
+
+func printBudgetRow(w *tabwriter.Writer, name string, b models.BudgetStatus) {
+	limit, left, status := "-", "-", "-"
+	if b.Limit > 0 {
+		limit = fmt.Sprintf("$%.2f", b.Limit)
+		left = fmt.Sprintf("$%.2f", b.Remaining)
+		status = b.Status
+		if b.Status != models.BudgetOnTrack {
+			status += " ⚠"
+		}
+	}
+	fmt.Fprintf(w, "%s\t$%.2f\t%s\t%s\t$%.2f\t%s\n", name, b.Spent, limit, left, b.Projected, status)
+}
+
+// PrintBudgetJSON exports the budget summary as structured JSON. It is not a
+// versioned models.Output document and is not covered by qcli schema.
+func PrintBudgetJSON(rows []BudgetRow, total models.BudgetStatus) {
+	b, _ := json.MarshalIndent(struct {
+		Providers []BudgetRow         `json:"providers"`
+		Total     models.BudgetStatus `json:"total"`
+	}{rows, total}, "", "  ")
+	fmt.Println(string(b))
+}
+
+// formatBudget renders a budget cell such as "$12/$50", flagged with ⚠ when
+// the month-end projection exceeds the budget and "over" once it is spent.
+func formatBudget(b *models.BudgetStatus) string {
+	if b == nil {
+		return "-"
+	}
+	s := fmt.Sprintf("$%.0f/$%.0f", b.Spent, b.Limit)
+	switch b.Status {
+	case models.BudgetAtRisk:
+		s += " ⚠"
+	case models.BudgetOver:
+		s += " over"
+	}
+	return s
+}
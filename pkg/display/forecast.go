@@ -0,0 +1,111 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+)
+
+// BacktestRow holds every model's backtest result for one provider.
+type BacktestRow struct {
+	Provider string                     `json:"provider"`
+	Days     int                        `json:"days"`
+	Results  []predictor.BacktestResult `json:"results"`
+}
+
+// PrintForecast renders one row per provider with its forecast at the end of
+// the quota window.
+func PrintForecast(reports []*models.ProviderReport) {
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Provider\tModel\tForecast\t80% Range\tWhen\tExtra Cost\tConfidence")
+	for _, rep := range reports {
+		pred := rep.Prediction
+		switch {
+		case rep.ErrorMsg != "":
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t⚠  %s\n", rep.Name, rep.ErrorMsg)
+		case pred == nil || pred.PredictedMonthlyRequests == 0:
+			conf := "-"
+			if pred != nil {
+				conf = pred.Confidence
+			}
+			fmt.Fprintf(w, "%s\t%s\t-\t-\t-\t-\t%s\n", rep.Name, dashIfEmpty(modelOf(pred)), conf)
+		default:
+			amount := formatAmount(rep, pred.PredictedMonthlyRequests)
+			if rep.Entitlement > 0 && rep.Unit != models.UnitPercent {
+				amount += fmt.Sprintf("/%d", rep.Entitlement)
+			}
+			rng, cost := "-", "-"
+			var iv *models.PredictionInterval
+			if len(pred.Intervals) > 0 {
+				iv = &pred.Intervals[0]
+				rng = formatAmount(rep, iv.RequestsLow) + "–" + formatAmount(rep, iv.RequestsHigh)
+			}
+			if pred.PredictedExtraCost > 0 || (iv != nil && iv.CostHigh > 0) {
+				cost = fmt.Sprintf("$%.2f", pred.PredictedExtraCost)
+				if iv != nil {
+					cost += fmt.Sprintf(" ($%.2f–$%.2f)", iv.CostLow, iv.CostHigh)
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", rep.Name, pred.Model, withUnit(rep, amount),
+				rng, horizonLabel(pred), cost, pred.Confidence)
+		}
+	}
+	w.Flush()
+}
+
+// PrintBacktest renders the MAPE of every model per provider, marking the best one.
+func PrintBacktest(rows []BacktestRow) {
+	if len(rows) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprint(w, "Provider\tDays")
+	for _, r := range rows[0].Results {
+		fmt.Fprintf(w, "\t%s", r.Model)
+	}
+	fmt.Fprintln(w)
+
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%d", row.Provider, row.Days)
+		best := -1
+		for i, r := range row.Results {
+			if r.Points > 0 && (best < 0 || r.MAPE < row.Results[best].MAPE) {
+				best = i
+			}
+		}
+		for i, r := range row.Results {
+			switch {
+			case r.Points == 0:
+				fmt.Fprint(w, "\t-")
+			case i == best:
+				fmt.Fprintf(w, "\t%.1f%% *", r.MAPE)
+			default:
+				fmt.Fprintf(w, "\t%.1f%%", r.MAPE)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Println("\nMAPE of next-day forecasts (lower is better, * = best). '-' means too little history.")
+}
+
+// PrintBacktestJSON exports backtest results as a JSON array. It is not a
+// versioned models.Output document and is not covered by qcli schema.
+func PrintBacktestJSON(rows []BacktestRow) {
+	b, _ := json.MarshalIndent(rows, "", "  ")
+	fmt.Println(string(b))
+}
+
+func modelOf(pred *models.PredictionReport) string {
+	if pred == nil {
+		return ""
+	}
+	return pred.Model
+}
@@ -0,0 +1,58 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/JValdivia23/quota-cli/pkg/providers"
+)
+
+// PrintDetections renders every catalog provider with its availability, the
+// provenance of its credential and what it reports.
+func PrintDetections(detections []providers.Detection) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Provider\tID\tStatus\tSource\tCredential\tReports")
+	for _, d := range detections {
+		status := "✗ not found"
+		if d.Error != "" {
+			status = "✗ " + d.Error
+		} else if d.Available {
+			status = "✓ detected"
+		}
+		id := d.ID
+		if len(d.Aliases) > 0 {
+			id += " (" + strings.Join(d.Aliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", d.Name, id, status, dashIfEmpty(d.Source), dashIfEmpty(d.Credential), capabilityList(d.Capabilities))
+	}
+	w.Flush()
+}
+
+// capabilityList names a provider's capabilities, e.g. "history, tokens".
+func capabilityList(c providers.Capabilities) string {
+	var names []string
+	for _, f := range []struct {
+		on   bool
+		name string
+	}{
+		{c.History, "history"},
+		{c.MultiAccount, "accounts"},
+		{c.Cost, "cost"},
+		{c.Tokens, "tokens"},
+	} {
+		if f.on {
+			names = append(names, f.name)
+		}
+	}
+	return dashIfEmpty(strings.Join(names, ", "))
+}
+
+// PrintDetectionsJSON exports provider detections as a JSON array. It is not a
+// versioned models.Output document and is not covered by qcli schema.
+func PrintDetectionsJSON(detections []providers.Detection) {
+	b, _ := json.MarshalIndent(detections, "", "  ")
+	fmt.Println(string(b))
+}
@@ -0,0 +1,175 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+)
+
+// historyDays is the number of most recent days shown per provider.
+const historyDays = 7
+
+// PrintReport renders one section per provider with its daily history,
+// totals, trend and monthly forecast.
+func PrintReport(reports []*models.ProviderReport) {
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+	for i, rep := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("== %s (%s) ==\n", rep.Name, rep.Type)
+
+		if rep.ErrorMsg != "" {
+			fmt.Printf("⚠  %s\n", rep.ErrorMsg)
+			continue
+		}
+
+		history := lastDays(rep.History, historyDays)
+		if len(history) == 0 {
+			fmt.Println("No usage history available.")
+		} else {
+			printHistory(rep, history)
+		}
+		if rep.Type == models.TypePayAsYouGo {
+			fmt.Printf("This month: $%.2f spent\n", rep.MonthToDate)
+		}
+
+		if rep.Exhaustion != nil {
+			line := formatExhaustion(rep.Exhaustion)
+			if rep.Exhaustion.BeforeReset {
+				line += " (before reset)"
+			}
+			fmt.Printf("Runs out:   %s, using %.1f/hour\n", line, rep.Exhaustion.BurnPerHour)
+		}
+		if rep.Prediction != nil {
+			printPrediction(rep)
+		}
+	}
+}
+
+func printHistory(rep *models.ProviderReport, history []models.DailyUsage) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Date\t%s\tBilled\n", capitalize(unitLabel(rep)))
+
+	var totalUsed, totalBilled float64
+	for _, d := range history {
+		fmt.Fprintf(w, "%s\t%s\t$%.2f\n", d.Date, formatAmount(rep, d.IncludedRequests), d.BilledAmount)
+		totalUsed += d.IncludedRequests
+		totalBilled += d.BilledAmount
+	}
+	fmt.Fprintf(w, "Total\t%s\t$%.2f\n", formatAmount(rep, totalUsed), totalBilled)
+	w.Flush()
+
+	if trend := predictor.CalculateTrend(history); trend != 0 {
+		arrow := "↑"
+		if trend < 0 {
+			arrow = "↓"
+		}
+		fmt.Printf("Trend:      %s %+.0f%% (recent days vs earlier days)\n", arrow, trend)
+	} else {
+		fmt.Println("Trend:      → flat")
+	}
+}
+
+func printPrediction(rep *models.ProviderReport) {
+	pred := rep.Prediction
+	if pred.PredictedMonthlyRequests > 0 {
+		amount := withUnit(rep, formatAmount(rep, pred.PredictedMonthlyRequests))
+		if rep.Entitlement > 0 && rep.Unit != models.UnitPercent {
+			amount = fmt.Sprintf("%s of %d %s", formatAmount(rep, pred.PredictedMonthlyRequests), rep.Entitlement, unitLabel(rep))
+		}
+		line := amount + " " + horizonLabel(pred)
+		if pred.PredictedExtraCost > 0 {
+			line += fmt.Sprintf(", ≈ $%.2f", pred.PredictedExtraCost)
+			if rep.Entitlement > 0 {
+				line += " overage"
+			}
+			if pred.Price != nil {
+				line += fmt.Sprintf(" at $%g/%s", pred.Price.PerUnit, pred.Price.Unit)
+			}
+		}
+		fmt.Printf("Forecast:   %s\n", line)
+		for _, iv := range pred.Intervals {
+			fmt.Printf("  %d%%:      %s\n", iv.Level, formatInterval(rep, iv))
+		}
+	}
+	fmt.Printf("Confidence: %s\n", pred.Confidence)
+}
+
+// formatInterval renders an interval such as "70–90 requests, $1.20–$2.00".
+func formatInterval(rep *models.ProviderReport, iv models.PredictionInterval) string {
+	s := withUnit(rep, formatAmount(rep, iv.RequestsLow)+"–"+formatAmount(rep, iv.RequestsHigh))
+	if iv.CostHigh > 0 {
+		s += fmt.Sprintf(", $%.2f–$%.2f", iv.CostLow, iv.CostHigh)
+	}
+	return s
+}
+
+// horizonLabel describes when the forecast applies, e.g. "at reset Thu 10/22 14:00 (7-day window)".
+func horizonLabel(pred *models.PredictionReport) string {
+	if pred.ResetAt == nil {
+		return "by month end"
+	}
+	label := "at reset " + pred.ResetAt.In(Location).Format("Mon 01/02 15:04")
+	switch {
+	case pred.WindowDays <= 0:
+	case pred.WindowDays < 1:
+		label += fmt.Sprintf(" (%.0f-hour window)", pred.WindowDays*24)
+	case pred.WindowDays >= 28:
+		label += " (monthly window)"
+	default:
+		label += fmt.Sprintf(" (%.0f-day window)", pred.WindowDays)
+	}
+	return label
+}
+
+// lastDays returns the newest n entries of history in chronological order.
+func lastDays(history []models.DailyUsage, n int) []models.DailyUsage {
+	sorted := make([]models.DailyUsage, len(history))
+	copy(sorted, history)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+	if len(sorted) > n {
+		sorted = sorted[len(sorted)-n:]
+	}
+	return sorted
+}
+
+// unitLabel names what the provider's usage is counted in: the unit it
+// reports (e.g. "%" for percentage quotas), tokens, or requests.
+func unitLabel(rep *models.ProviderReport) string {
+	switch {
+	case rep.Unit != "":
+		return rep.Unit
+	case rep.Type == models.TypeTokensBased:
+		return "tokens"
+	}
+	return "requests"
+}
+
+// withUnit appends the unit to an amount: "42%" or "120 requests".
+func withUnit(rep *models.ProviderReport, amount string) string {
+	if unit := unitLabel(rep); unit != models.UnitPercent {
+		return amount + " " + unit
+	}
+	return amount + models.UnitPercent
+}
+
+func formatAmount(rep *models.ProviderReport, v float64) string {
+	if rep.Type == models.TypeTokensBased {
+		return formatTokens(int64(v))
+	}
+	return fmt.Sprintf("%.0f", v)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -3,10 +3,12 @@ package display
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
@@ -29,24 +31,39 @@ func PrintTable(reports []*models.ProviderReport) {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
 
+	// The Budget column only appears once a provider has a budget.
+	showBudget := false
+	for _, rep := range reports {
+		if rep.Budget != nil {
+			showBudget = true
+		}
+	}
+	row := func(name, refresh, use, runsOut, budget, metrics string) {
+		if showBudget {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", name, refresh, use, runsOut, budget, metrics)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, refresh, use, runsOut, metrics)
+		}
+	}
+
 	// Header
-	fmt.Fprintln(w, "Provider\tRefresh\tUse\tKey Metrics")
-	printDivider(w, reports)
+	row("Provider", "Refresh", "Use", "Runs Out", "Budget", "Key Metrics")
+	printDivider(reports, row)
 
 	for _, rep := range reports {
 		// Error row (provider reached but API call failed)
 		if rep.ErrorMsg != "" {
-			fmt.Fprintf(w, "%s\t%s\t-\t⚠  %s\n",
-				rep.Name, truncate("(unavailable)", 20), rep.ErrorMsg)
+			row(rep.Name, truncate("(unavailable)", 20), "-", "-", "-", "⚠  "+rep.ErrorMsg)
 			continue
 		}
+		budget := formatBudget(rep.Budget)
 
 		switch rep.Type {
 
 		case models.TypeQuotaBased:
 			if len(rep.Accounts) > 0 {
 				// Multi-account: provider name row + indented sub-rows
-				fmt.Fprintf(w, "%s\t\t\t\n", rep.Name)
+				row(rep.Name, "", "", "", budget, "")
 				for _, acc := range rep.Accounts {
 					used := acc.Entitlement - acc.Remaining
 					pct := 0
@@ -54,12 +71,19 @@ func PrintTable(reports []*models.ProviderReport) {
 						pct = (used * 100) / acc.Entitlement
 					}
 					metricStr := ""
-					if acc.Entitlement > 0 {
+					if acc.Entitlement > 0 && acc.Unit != "" {
+						metricStr = fmt.Sprintf("%d/%d %s remaining", acc.Remaining, acc.Entitlement, acc.Unit)
+					} else if acc.Entitlement > 0 {
 						metricStr = fmt.Sprintf("%d/%d remaining", acc.Remaining, acc.Entitlement)
 					} else {
 						metricStr = "unlimited"
 					}
-					fmt.Fprintf(w, "  ↳\t%s\t%d%%\t%s\n", acc.Email, pct, metricStr)
+					if acc.Note != "" {
+						metricStr += " (" + acc.Note + ")"
+					}
+					row("  ↳", formatReset(acc.Email, acc.ResetAt), formatPct(pct, rep.Threshold), formatExhaustion(acc.Exhaustion), "", metricStr)
+					windowRows(row, "    ·", acc.Windows, rep.Threshold)
+					modelRows(row, "    ·", acc.Models, rep.Threshold)
 				}
 			} else {
 				// Single-account quota
@@ -68,26 +92,32 @@ func PrintTable(reports []*models.ProviderReport) {
 				if rep.Entitlement > 0 {
 					pct = (used * 100) / rep.Entitlement
 				}
-				refresh := dashIfEmpty(rep.RefreshTime)
+				refresh := refreshLabel(rep.WindowKind, rep.ResetAt)
 				metricStr := ""
 				if rep.Entitlement > 0 {
 					metricStr = fmt.Sprintf("%d/%d remaining", rep.Remaining, rep.Entitlement)
 				} else {
 					metricStr = "unlimited"
 				}
-				fmt.Fprintf(w, "%s\t%s\t%d%%\t%s\n", rep.Name, refresh, pct, metricStr)
+				row(rep.Name, refresh, formatPct(pct, rep.Threshold), formatExhaustion(rep.Exhaustion), budget, metricStr)
+				windowRows(row, "  ↳", rep.Windows, rep.Threshold)
+				modelRows(row, "  ↳", rep.Models, rep.Threshold)
 			}
 
 		case models.TypeTokensBased:
-			refresh := dashIfEmpty(rep.RefreshTime)
-			fmt.Fprintf(w, "%s\t%s\t-\t%s tokens used\n",
-				rep.Name, refresh, formatTokens(rep.TokensUsed))
+			refresh := refreshLabel(rep.WindowKind, rep.ResetAt)
+			row(rep.Name, refresh, "-", "-", budget, formatTokens(rep.TokensUsed)+" tokens used")
 
 		case models.TypePayAsYouGo:
-			fmt.Fprintf(w, "%s\t-\t-\t$%.2f spent\n", rep.Name, rep.Cost)
+			row(rep.Name, "-", "-", "-", budget, fmt.Sprintf("$%.2f spent", rep.Cost))
 
 		default:
-			fmt.Fprintf(w, "%s\t-\t-\t-\n", rep.Name)
+			row(rep.Name, "-", "-", "-", budget, "-")
+		}
+
+		// A stale report stands in for a failed fetch; say how old it is and why.
+		if rep.Stale && rep.CachedAt != nil {
+			row("  ↳", "", "", "", "", fmt.Sprintf("⚠  cached %s: %s", formatAge(*rep.CachedAt), rep.StaleReason))
 		}
 	}
 
@@ -95,7 +125,7 @@ func PrintTable(reports []*models.ProviderReport) {
 }
 
 // printDivider prints a separator row that adapts to the terminal.
-func printDivider(w *tabwriter.Writer, reports []*models.ProviderReport) {
+func printDivider(reports []*models.ProviderReport, row func(name, refresh, use, runsOut, budget, metrics string)) {
 	// Find the longest provider name to size the first column
 	maxName := 8 // minimum "Provider" width
 	for _, r := range reports {
@@ -104,8 +134,67 @@ func printDivider(w *tabwriter.Writer, reports []*models.ProviderReport) {
 		}
 	}
 	seg := func(n int) string { return strings.Repeat("─", n) }
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-		seg(maxName+2), seg(22), seg(6), seg(20))
+	row(seg(maxName+2), seg(22), seg(6), seg(11), seg(10), seg(20))
+}
+
+// windowRows prints one sub-row per quota window when there are several,
+// marking the binding one.
+func windowRows(row func(name, refresh, use, runsOut, budget, metrics string), prefix string, windows []models.QuotaWindow, threshold int) {
+	if len(windows) < 2 {
+		return
+	}
+	for _, win := range windows {
+		metrics := fmt.Sprintf("%.0f%% remaining", max(100-win.UsedPercent, 0))
+		if win.Limit > 0 {
+			used := int(float64(win.Limit) * win.UsedPercent / 100)
+			metrics = fmt.Sprintf("%d/%d remaining", win.Limit-used, win.Limit)
+		}
+		if win.Binding {
+			metrics += " ◀ binding"
+		}
+		row(prefix, formatReset(win.Name, win.ResetAt), formatPct(int(win.UsedPercent), threshold), "-", "", metrics)
+	}
+}
+
+// AllModels expands model quota buckets to one sub-row each; otherwise only
+// the tightest bucket is shown, with a count of the others.
+var AllModels bool
+
+// modelRows prints the model quota buckets as sub-rows: the tightest one, or
+// all of them when AllModels is set. Request buckets are labelled by model
+// alone, others also by token type.
+func modelRows(row func(name, refresh, use, runsOut, budget, metrics string), prefix string, buckets []models.ModelQuota, threshold int) {
+	hidden := 0
+	if !AllModels && len(buckets) > 1 {
+		tightest := buckets[0]
+		for _, b := range buckets[1:] {
+			if b.RemainingFraction < tightest.RemainingFraction {
+				tightest = b
+			}
+		}
+		hidden = len(buckets) - 1
+		buckets = []models.ModelQuota{tightest}
+	}
+	for _, b := range buckets {
+		label := b.Model
+		if b.TokenType != "" && b.TokenType != "REQUESTS" {
+			label += " (" + strings.ToLower(strings.ReplaceAll(b.TokenType, "_", " ")) + ")"
+		}
+		pct := int(math.Round((1 - b.RemainingFraction) * 100))
+		metrics := fmt.Sprintf("%.0f%% remaining", b.RemainingFraction*100)
+		if hidden > 0 {
+			metrics += fmt.Sprintf(" (+%d more, --all-models)", hidden)
+		}
+		row(prefix, formatReset(label, b.ResetAt), formatPct(pct, threshold), "-", "", metrics)
+	}
+}
+
+// formatPct renders a usage percentage, flagging it once it reaches threshold.
+func formatPct(pct, threshold int) string {
+	if threshold > 0 && pct >= threshold {
+		return fmt.Sprintf("%d%% ⚠", pct)
+	}
+	return fmt.Sprintf("%d%%", pct)
 }
 
 func dashIfEmpty(s string) string {
@@ -135,12 +224,19 @@ func formatTokens(n int64) string {
 	return fmt.Sprintf("%d", n)
 }
 
-// PrintJSON exports provider reports as structured JSON.
+// PrintJSON exports provider reports as a versioned models.Output document
+// (see qcli schema).
 func PrintJSON(reports []*models.ProviderReport) {
-	output := make(map[string]*models.ProviderReport, len(reports))
-	for _, rep := range reports {
-		output[rep.Name] = rep
-	}
-	b, _ := json.MarshalIndent(output, "", "  ")
+	sorted := make([]*models.ProviderReport, len(reports))
+	copy(sorted, reports)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	host, _ := os.Hostname()
+	b, _ := json.MarshalIndent(models.Output{
+		SchemaVersion: models.SchemaVersion,
+		GeneratedAt:   time.Now().UTC().Truncate(time.Second),
+		Host:          host,
+		Reports:       sorted,
+	}, "", "  ")
 	fmt.Println(string(b))
 }
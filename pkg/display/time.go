@@ -0,0 +1,81 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// Location is the time zone reset and exhaustion times are shown in.
+var Location = time.Local
+
+// refreshLabel renders when a quota resets, e.g. "Weekly: in 6d (03/03)", from
+// its window kind and reset instant. It returns "-" when neither is known.
+func refreshLabel(kind string, resetAt *time.Time) string {
+	if kind == "" && resetAt == nil {
+		return "-"
+	}
+	label := capitalize(kind)
+	if label == "" {
+		label = "Resets"
+	}
+	return formatReset(label, resetAt)
+}
+
+// formatReset renders a labelled reset time: relative and, for orientation,
+// absolute, such as "5-hour: in 3h (15:00)" or "Monthly: in 4d (03/01)".
+func formatReset(label string, t *time.Time) string {
+	if t == nil {
+		return label
+	}
+	at := t.In(Location)
+	until := time.Until(at).Round(time.Minute)
+	switch {
+	case until <= 0:
+		return fmt.Sprintf("%s: now (%s)", label, at.Format("01/02 15:04"))
+	case until < time.Hour:
+		return fmt.Sprintf("%s: in %dm (%s)", label, int(until.Minutes()), at.Format("15:04"))
+	case until < 24*time.Hour:
+		return fmt.Sprintf("%s: in %dh (%s)", label, int(until.Hours()), at.Format("15:04"))
+	default:
+		return fmt.Sprintf("%s: in %dd (%s)", label, int(until.Hours()/24), at.Format("01/02"))
+	}
+}
+
+// formatExhaustion renders when a quota runs out, e.g. "Thu 14:00 ⚠" when that
+// is before the reset. Times more than a week away are shown as a date.
+func formatExhaustion(e *models.Exhaustion) string {
+	if e == nil {
+		return "-"
+	}
+	at := e.At.In(Location)
+	var s string
+	switch until := time.Until(at); {
+	case until <= 0:
+		s = "now"
+	case until < 7*24*time.Hour:
+		s = at.Format("Mon 15:04")
+	default:
+		s = at.Format("01/02")
+	}
+	if e.BeforeReset {
+		s += " ⚠"
+	}
+	return s
+}
+
+// formatAge renders how long ago t was, e.g. "45s ago", "12m ago" or "3h ago".
+func formatAge(t time.Time) string {
+	age := time.Since(t)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds ago", max(int(age.Seconds()), 0))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
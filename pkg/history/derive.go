@@ -0,0 +1,173 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+)
+
+// DeriveDailyUsage turns consecutive snapshots into per-day usage for every
+// local day from `from` to `to`, oldest first.
+//
+// Usage between two snapshots is the increase of the provider's counters:
+// requests or tokens consumed go to IncludedRequests, pay-as-you-go spend to
+// BilledAmount. A quota counter only resets when its window moves on (ResetAt
+// or WindowStart moves forward), in which case its new value is counted as the
+// usage since the reset; a month-to-date cost resets at the start of a month.
+// Any other decrease (a rolling window forgetting old usage, files deleted
+// from a storage quota) counts as no usage. When snapshots are hours or days
+// apart the usage is spread over that interval in proportion to time, so
+// missing days show an estimate instead of zero followed by a spike.
+// Days before the first snapshot are left out, since nothing is known about them.
+func DeriveDailyUsage(snaps []Snapshot, from, to time.Time) []models.DailyUsage {
+	loc := from.Location()
+	requests := make(map[string]float64)
+	billed := make(map[string]float64)
+	for i := 1; i < len(snaps); i++ {
+		prev, cur := &snaps[i-1].Report, &snaps[i].Report
+		start, end := snaps[i-1].TakenAt.In(loc), snaps[i].TakenAt.In(loc)
+
+		before := usageCounters(prev, false)
+		for key, c := range usageCounters(cur, false) {
+			if b, seen := before[key]; seen {
+				c.At = end
+				addIncrease(requests, start, end, b.Used, c.Used, c.ResetSince(b))
+			}
+		}
+
+		if prev.Type == models.TypePayAsYouGo && cur.Type == models.TypePayAsYouGo {
+			// Cost is spend (lifetime or month-to-date), never a balance, so
+			// credit top-ups do not show up as usage.
+			var reset *time.Time
+			if month := time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, loc); month.After(start) {
+				reset = &month
+			}
+			addIncrease(billed, start, end, prev.Cost, cur.Cost, reset)
+		}
+	}
+
+	if len(snaps) == 0 {
+		return nil
+	}
+	if first := snaps[0].TakenAt.In(loc); first.After(from) {
+		from = first
+	}
+
+	var out []models.DailyUsage
+	for day := startOfDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		out = append(out, models.DailyUsage{Date: date, IncludedRequests: requests[date], BilledAmount: billed[date]})
+	}
+	return out
+}
+
+// addIncrease spreads how much a counter grew between two snapshots over the
+// interval. When the counter was reset at the given instant, its new value is
+// the usage since then; otherwise a decrease counts as zero.
+func addIncrease(perDay map[string]float64, start, end time.Time, before, after float64, reset *time.Time) {
+	if reset == nil {
+		spread(perDay, start, end, max(after-before, 0))
+		return
+	}
+	if reset.After(start) && reset.Before(end) {
+		start = *reset
+	}
+	spread(perDay, start, end, after)
+}
+
+// spread distributes amount over the local days between start and end in
+// proportion to the time spent in each day.
+func spread(perDay map[string]float64, start, end time.Time, amount float64) {
+	if amount == 0 {
+		return
+	}
+	total := end.Sub(start)
+	if total <= 0 {
+		perDay[end.Format("2006-01-02")] += amount
+		return
+	}
+	for cur := start; cur.Before(end); {
+		next := startOfDay(cur).AddDate(0, 0, 1)
+		if next.After(end) {
+			next = end
+		}
+		perDay[cur.Format("2006-01-02")] += amount * float64(next.Sub(cur)) / float64(total)
+		cur = next
+	}
+}
+
+// usageCounters extracts the consumption counters of a report, keyed by
+// account so one account's reset does not affect another. A quota with
+// several windows gets one counter per window (see WindowKey), since the
+// binding window, and so the headline figure, can change between snapshots.
+// With all unset only the longest window is kept, so daily usage is not
+// counted once per window.
+func usageCounters(rep *models.ProviderReport, all bool) map[string]predictor.Sample {
+	counters := make(map[string]predictor.Sample)
+	switch rep.Type {
+	case models.TypeTokensBased:
+		counters[""] = predictor.Sample{Used: float64(rep.TokensUsed), ResetAt: rep.ResetAt, WindowStart: rep.WindowStart}
+	case models.TypeQuotaBased:
+		if len(rep.Windows) > 0 {
+			windowCounters(counters, "", rep.Windows, all)
+		} else if rep.Entitlement > 0 {
+			counters[""] = predictor.Sample{Used: float64(rep.Entitlement - rep.Remaining), ResetAt: rep.ResetAt, WindowStart: rep.WindowStart}
+		}
+		for _, acc := range rep.Accounts {
+			if len(acc.Windows) > 0 {
+				windowCounters(counters, AccountKey(acc), acc.Windows, all)
+			} else if acc.Entitlement > 0 {
+				counters[AccountKey(acc)] = predictor.Sample{Used: float64(acc.Entitlement - acc.Remaining), ResetAt: acc.ResetAt}
+			}
+		}
+	}
+	return counters
+}
+
+// windowCounters adds the percentage used of each window (or of the longest
+// one only) under WindowKey(account, name).
+func windowCounters(counters map[string]predictor.Sample, account string, windows []models.QuotaWindow, all bool) {
+	longest := 0
+	for i, w := range windows {
+		if w.WindowSeconds > windows[longest].WindowSeconds {
+			longest = i
+		}
+	}
+	for i, w := range windows {
+		if all || i == longest {
+			counters[WindowKey(account, w.Name)] = predictor.Sample{Used: min(max(w.UsedPercent, 0), 100), ResetAt: w.ResetAt}
+		}
+	}
+}
+
+// WindowKey identifies one quota window of the report (account "") or of an
+// account across snapshots.
+func WindowKey(account, window string) string {
+	return account + "@" + window
+}
+
+// SeriesKey returns the UsageSeries key of the headline counter of the report
+// (account "") or an account: the binding window's when it has several.
+func SeriesKey(account string, windows []models.QuotaWindow) string {
+	for _, w := range windows {
+		if w.Binding {
+			return WindowKey(account, w.Name)
+		}
+	}
+	return account
+}
+
+// AccountKey identifies an account across snapshots. Labels of some providers
+// embed the time to reset, so the index is used when there is no account ID.
+func AccountKey(acc models.Account) string {
+	if acc.AccountID != "" {
+		return acc.AccountID + "/" + acc.Email
+	}
+	return fmt.Sprintf("#%d", acc.Index)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,226 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func quotaReport(used int) *models.ProviderReport {
+	return &models.ProviderReport{
+		ID:          "copilot",
+		Name:        "GitHub Copilot",
+		Type:        models.TypeQuotaBased,
+		Entitlement: 300,
+		Remaining:   300 - used,
+	}
+}
+
+func withReset(rep *models.ProviderReport, resetAt time.Time) models.ProviderReport {
+	rep.ResetAt = &resetAt
+	return *rep
+}
+
+func TestDeriveDailyUsage(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2026, 10, d, h, 0, 0, 0, time.UTC) }
+	snaps := []Snapshot{
+		{TakenAt: at(2, 0), Report: withReset(quotaReport(100), at(4, 15))},
+		{TakenAt: at(2, 12), Report: withReset(quotaReport(112), at(4, 15))},
+		// Two days without snapshots: 48 requests spread over 48 hours.
+		{TakenAt: at(4, 12), Report: withReset(quotaReport(160), at(4, 15))},
+		// The quota reset at 15:00, so everything used since counts.
+		{TakenAt: at(4, 18), Report: withReset(quotaReport(6), at(11, 15))},
+	}
+
+	got := DeriveDailyUsage(snaps, at(2, 0), at(4, 20))
+	want := []float64{24, 24, 18}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d days, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].IncludedRequests != w {
+			t.Errorf("Day %s: expected %.0f, got %.2f", got[i].Date, w, got[i].IncludedRequests)
+		}
+	}
+	if got[0].Date != "2026-10-02" || got[2].Date != "2026-10-04" {
+		t.Errorf("Expected oldest-first dates, got %s..%s", got[0].Date, got[2].Date)
+	}
+}
+
+func TestDeriveDailyUsageRollingDecline(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 10, 2, h, 0, 0, 0, time.UTC) }
+	reset := at(23)
+	rolling := func(used int) models.ProviderReport { return withReset(quotaReport(used), reset) }
+	snaps := []Snapshot{
+		{TakenAt: at(0), Report: rolling(100)},
+		// Old usage aged out of a rolling window: not a reset, and no usage.
+		{TakenAt: at(6), Report: rolling(70)},
+		{TakenAt: at(12), Report: rolling(80)},
+		// Files deleted from a storage quota, which reports no reset time.
+		{TakenAt: at(14), Report: *quotaReport(50)},
+		{TakenAt: at(16), Report: *quotaReport(55)},
+	}
+
+	got := DeriveDailyUsage(snaps, at(0), at(20))
+	if len(got) != 1 || got[0].IncludedRequests != 15 {
+		t.Errorf("Expected 15 requests (10 + 5) with declines ignored, got %+v", got)
+	}
+}
+
+func TestDeriveDailyUsageBindingWindowSwitch(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2026, 10, 2, h, 0, 0, 0, time.UTC) }
+	claude := func(hourly, weekly float64) models.ProviderReport {
+		windows := []models.QuotaWindow{
+			{Name: "5-hour", UsedPercent: hourly, WindowSeconds: 5 * 3600},
+			{Name: "7-day", UsedPercent: weekly, WindowSeconds: 7 * 24 * 3600},
+		}
+		binding := 1
+		if hourly > weekly {
+			binding = 0
+		}
+		windows[binding].Binding = true
+		used := int(windows[binding].UsedPercent)
+		return models.ProviderReport{ID: "claude", Name: "Claude", Type: models.TypeQuotaBased,
+			Entitlement: 100, Remaining: 100 - used, UsagePercentage: used, Windows: windows}
+	}
+	// The headline moves from the 7-day window (40%) to the 5-hour one (50%),
+	// but only 2% of the weekly quota was used.
+	snaps := []Snapshot{
+		{TakenAt: at(6), Report: claude(10, 40)},
+		{TakenAt: at(8), Report: claude(50, 42)},
+	}
+
+	got := DeriveDailyUsage(snaps, at(0), at(12))
+	if len(got) != 1 || got[0].IncludedRequests != 2 {
+		t.Errorf("Expected 2%% used from the 7-day window, got %+v", got)
+	}
+
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"), 0)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+	for _, snap := range snaps {
+		if err := store.Save(&snap.Report, snap.TakenAt); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	series, err := store.UsageSeries("claude", at(0))
+	if err != nil {
+		t.Fatalf("UsageSeries: %v", err)
+	}
+	hourly := series[SeriesKey("", snaps[1].Report.Windows)]
+	if len(hourly) != 2 || hourly[0].Used != 10 || hourly[1].Used != 50 {
+		t.Errorf("Expected the binding 5-hour window's series 10 -> 50, got %+v", hourly)
+	}
+}
+
+func TestDeriveDailySpend(t *testing.T) {
+	at := func(m time.Month, d, h int) time.Time { return time.Date(2026, m, d, h, 0, 0, 0, time.UTC) }
+	payg := func(cost, credits float64) models.ProviderReport {
+		return models.ProviderReport{Name: "OpenCode Zen", Type: models.TypePayAsYouGo, Cost: cost, Credits: credits}
+	}
+	snaps := []Snapshot{
+		{TakenAt: at(9, 30, 12), Report: payg(40, 50)},
+		// The month-to-date counter rolled over: $1.50 spent since midnight.
+		{TakenAt: at(10, 1, 12), Report: payg(1.5, 50)},
+		// A $20 top-up is not spend.
+		{TakenAt: at(10, 1, 18), Report: payg(2, 70)},
+		// Overnight spend is split between the two days.
+		{TakenAt: at(10, 2, 6), Report: payg(3.25, 70)},
+	}
+
+	got := DeriveDailyUsage(snaps, at(10, 1, 0), at(10, 2, 12))
+	want := []float64{2.625, 0.625}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d days, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].BilledAmount != w {
+			t.Errorf("Day %s: expected $%.3f, got $%.4f", got[i].Date, w, got[i].BilledAmount)
+		}
+		if got[i].IncludedRequests != 0 {
+			t.Errorf("Day %s: expected no requests for pay-as-you-go, got %.2f", got[i].Date, got[i].IncludedRequests)
+		}
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "history.db"), 0)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.Local)
+	for i, used := range []int{10, 40} {
+		if err := store.Save(quotaReport(used), now.Add(time.Duration(i-1)*time.Hour)); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if err := store.Save(&models.ProviderReport{ID: "copilot", Name: "GitHub Copilot", ErrorMsg: "boom"}, now); err != nil {
+		t.Fatalf("Save error row: %v", err)
+	}
+
+	snaps, err := store.Snapshots("copilot", now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if len(snaps) != 2 || snaps[0].Report.Remaining != 290 {
+		t.Fatalf("Expected 2 snapshots oldest first without the error row, got %+v", snaps)
+	}
+
+	days, err := store.DailyUsage("copilot", 7, now)
+	if err != nil {
+		t.Fatalf("DailyUsage: %v", err)
+	}
+	if len(days) != 1 || days[0].Date != "2026-10-15" {
+		t.Fatalf("Expected only the day snapshots exist for, got %+v", days)
+	}
+	var total float64
+	for _, d := range days {
+		total += d.IncludedRequests
+	}
+	if total != 30 {
+		t.Errorf("Expected 30 requests derived, got %.0f", total)
+	}
+}
+
+func TestStoreSkipsRapidSnapshotsAndPrunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	store, err := Open(path, 0)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	old := now.Add(-100 * 24 * time.Hour)
+	for _, at := range []time.Time{old, now.Add(-time.Hour), now.Add(-time.Hour + 10*time.Second), now} {
+		if err := store.Save(quotaReport(10), at); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	snaps, err := store.Snapshots("copilot", old)
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if len(snaps) != 3 {
+		t.Fatalf("Expected the snapshot 10s after another to be skipped, got %d snapshots", len(snaps))
+	}
+	store.Close()
+
+	store, err = Open(path, DefaultRetention)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer store.Close()
+	snaps, err = store.Snapshots("copilot", old)
+	if err != nil {
+		t.Fatalf("Snapshots: %v", err)
+	}
+	if len(snaps) != 2 || !snaps[0].TakenAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected the 100-day-old snapshot to be pruned, got %+v", snaps)
+	}
+}
@@ -0,0 +1,228 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/predictor"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS snapshots (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	provider TEXT    NOT NULL,
+	taken_at INTEGER NOT NULL, -- unix seconds
+	report   TEXT    NOT NULL  -- JSON-encoded models.ProviderReport
+);
+CREATE INDEX IF NOT EXISTS idx_snapshots_provider_time ON snapshots(provider, taken_at);
+CREATE INDEX IF NOT EXISTS idx_snapshots_time ON snapshots(taken_at);
+`
+
+const (
+	// DefaultRetention is how long snapshots are kept when no retention is configured.
+	DefaultRetention = 90 * 24 * time.Hour
+	// minSnapshotInterval is the shortest gap between two snapshots of a
+	// provider; status bars refreshing every few seconds add nothing to history.
+	minSnapshotInterval = time.Minute
+)
+
+// Store persists ProviderReport snapshots so usage history can be derived for
+// providers that have no server-side history.
+type Store struct {
+	db *sql.DB
+}
+
+// Snapshot is one stored report and the time it was taken.
+type Snapshot struct {
+	TakenAt time.Time
+	Report  models.ProviderReport
+}
+
+// DefaultPath returns $XDG_DATA_HOME/qcli/history.db, defaulting to ~/.local/share.
+func DefaultPath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "qcli", "history.db"), nil
+}
+
+// Open opens (and if needed creates) the snapshot database at path and
+// deletes snapshots older than retention; 0 keeps them forever.
+func Open(path string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	// Several qcli processes (e.g. status bars) may write at once.
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init history db %s: %w", path, err)
+	}
+	s := &Store{db: db}
+	if retention > 0 {
+		if err := s.Prune(time.Now().Add(-retention)); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("prune history db %s: %w", path, err)
+		}
+	}
+	return s, nil
+}
+
+// Prune deletes every snapshot taken before the given time.
+func (s *Store) Prune(before time.Time) error {
+	_, err := s.db.Exec(`DELETE FROM snapshots WHERE taken_at < ?`, before.Unix())
+	return err
+}
+
+// Close releases the database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save records a successful report under its provider ID. Error rows and
+// reports taken less than a minute after the provider's previous snapshot are
+// skipped, and derived data (History, Prediction) is stripped so snapshots
+// stay small.
+func (s *Store) Save(rep *models.ProviderReport, at time.Time) error {
+	if rep == nil || rep.ErrorMsg != "" {
+		return nil
+	}
+	var last sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MAX(taken_at) FROM snapshots WHERE provider = ? AND taken_at <= ?`,
+		rep.ID, at.Unix()).Scan(&last); err != nil {
+		return err
+	}
+	if last.Valid && at.Sub(time.Unix(last.Int64, 0)) < minSnapshotInterval {
+		return nil
+	}
+	snap := *rep
+	snap.History, snap.Prediction = nil, nil
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO snapshots (provider, taken_at, report) VALUES (?, ?, ?)`,
+		rep.ID, at.Unix(), string(data))
+	return err
+}
+
+// Providers returns the IDs of every provider with recorded snapshots.
+func (s *Store) Providers() ([]string, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT provider FROM snapshots ORDER BY provider`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// Snapshots returns a provider's snapshots taken at or after since, oldest first.
+func (s *Store) Snapshots(provider string, since time.Time) ([]Snapshot, error) {
+	rows, err := s.db.Query(`SELECT taken_at, report FROM snapshots
+		WHERE provider = ? AND taken_at >= ? ORDER BY taken_at, id`, provider, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snaps []Snapshot
+	for rows.Next() {
+		var takenAt int64
+		var data string
+		if err := rows.Scan(&takenAt, &data); err != nil {
+			return nil, err
+		}
+		var rep models.ProviderReport
+		if err := json.Unmarshal([]byte(data), &rep); err != nil {
+			continue // skip rows written by an incompatible version
+		}
+		snaps = append(snaps, Snapshot{TakenAt: time.Unix(takenAt, 0), Report: rep})
+	}
+	return snaps, rows.Err()
+}
+
+// DailyUsage derives up to the last `days` days of usage for a provider from
+// its snapshots, oldest first.
+func (s *Store) DailyUsage(provider string, days int, now time.Time) ([]models.DailyUsage, error) {
+	start := startOfDay(now).AddDate(0, 0, -(days - 1))
+	snaps, err := s.window(provider, start)
+	if err != nil {
+		return nil, err
+	}
+	return DeriveDailyUsage(snaps, start, now), nil
+}
+
+// MonthToDate returns a pay-as-you-go provider's spend since the start of the
+// calendar month, derived from its snapshots. Spend before the first snapshot
+// is unknown, so the figure is a lower bound until a full month is recorded.
+func (s *Store) MonthToDate(provider string, now time.Time) (float64, error) {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	snaps, err := s.window(provider, start)
+	if err != nil {
+		return 0, err
+	}
+	var total float64
+	for _, d := range DeriveDailyUsage(snaps, start, now) {
+		total += d.BilledAmount
+	}
+	return total, nil
+}
+
+// UsageSeries returns the quota used per snapshot since the given time, keyed
+// like the snapshots' counters: "" for the report itself and AccountKey for
+// each account, or WindowKey for each window of those with several (see
+// SeriesKey).
+func (s *Store) UsageSeries(provider string, since time.Time) (map[string][]predictor.Sample, error) {
+	snaps, err := s.window(provider, since)
+	if err != nil {
+		return nil, err
+	}
+	series := make(map[string][]predictor.Sample)
+	for _, snap := range snaps {
+		for key, sample := range usageCounters(&snap.Report, true) {
+			sample.At = snap.TakenAt
+			series[key] = append(series[key], sample)
+		}
+	}
+	return series, nil
+}
+
+// window returns the snapshots taken since start plus the last one before it,
+// so usage across the window boundary is known.
+func (s *Store) window(provider string, start time.Time) ([]Snapshot, error) {
+	var prev sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MAX(taken_at) FROM snapshots WHERE provider = ? AND taken_at < ?`,
+		provider, start.Unix()).Scan(&prev); err != nil {
+		return nil, err
+	}
+	since := start
+	if prev.Valid {
+		since = time.Unix(prev.Int64, 0)
+	}
+	return s.Snapshots(provider, since)
+}
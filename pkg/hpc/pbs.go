@@ -0,0 +1,287 @@
+package hpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PBSConfig selects which PBS Pro projects to report and where charges come from.
+type PBSConfig struct {
+	// Projects are the project codes (Account_Name) to report.
+	Projects []string
+	// AccountingDir holds PBS accounting logs named YYYYMMDD
+	// (e.g. a readable copy of /var/spool/pbs/server_priv/accounting).
+	AccountingDir string
+	// AllocationCommand is a site command printing "<project> <allocated> <used>"
+	// lines in core-hours. When set, its figures are authoritative.
+	AllocationCommand string
+	// Allocations are the core-hours granted per project code for the period,
+	// used as the limit when the allocation command does not report one.
+	// Project codes are matched case-insensitively.
+	Allocations map[string]float64
+	// PeriodStart is the start of the allocation period; zero means the first
+	// day of the current month.
+	PeriodStart time.Time
+}
+
+// Configured reports whether enough was configured to query PBS.
+func (c PBSConfig) Configured() bool {
+	return len(c.Projects) > 0 || c.AllocationCommand != ""
+}
+
+// allocation returns the configured core-hours of project, or 0.
+func (c PBSConfig) allocation(project string) float64 {
+	for p, hours := range c.Allocations {
+		if strings.EqualFold(p, project) {
+			return hours
+		}
+	}
+	return 0
+}
+
+// CollectPBS returns one compute QuotaInfo (in core-hours) per project.
+// Usage comes from the allocation command when configured, otherwise from
+// finished jobs in the accounting logs plus running jobs in `qstat -f`.
+func CollectPBS(ctx context.Context, r Runner, cfg PBSConfig) ([]QuotaInfo, error) {
+	start := cfg.PeriodStart
+	if start.IsZero() {
+		now := time.Now()
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+
+	allocs := make(map[string]QuotaInfo)
+	if cfg.AllocationCommand != "" {
+		argv := strings.Fields(cfg.AllocationCommand)
+		if len(argv) == 0 {
+			return nil, fmt.Errorf("pbs.allocation_command is blank")
+		}
+		out, err := r.Run(ctx, argv[0], argv[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		allocs = ParseAllocationOutput(out)
+	}
+
+	projects := cfg.Projects
+	if len(projects) == 0 {
+		for p := range allocs {
+			projects = append(projects, p)
+		}
+		sort.Strings(projects)
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("no PBS projects configured")
+	}
+
+	// Without an authoritative allocation command, add up charges ourselves.
+	var charges map[string]float64
+	if cfg.AllocationCommand == "" {
+		charges = make(map[string]float64)
+		if cfg.AccountingDir != "" {
+			finished, err := ReadPBSAccounting(cfg.AccountingDir, start)
+			if err != nil {
+				return nil, err
+			}
+			for p, h := range finished {
+				charges[p] += h
+			}
+		}
+		out, err := r.Run(ctx, "qstat", "-f")
+		if err != nil {
+			return nil, err
+		}
+		for p, h := range ParseQstatF(out, start) {
+			charges[p] += h
+		}
+	}
+
+	infos := make([]QuotaInfo, 0, len(projects))
+	for _, p := range projects {
+		q, ok := allocs[p]
+		if !ok {
+			q = QuotaInfo{ProjectCode: p, Path: p, Type: "compute", Unit: "core-hours", Tool: "pbs"}
+		}
+		if charges != nil {
+			q.Used = charges[p]
+		}
+		if q.Limit == 0 {
+			q.Limit = cfg.allocation(p)
+		}
+		infos = append(infos, q)
+	}
+	return infos, nil
+}
+
+// ParseAllocationOutput parses "<project> <allocated> <used>" lines printed by a
+// site allocation command. Blank lines, "#" comments and header rows are skipped.
+func ParseAllocationOutput(out []byte) map[string]QuotaInfo {
+	allocs := make(map[string]QuotaInfo)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		limit, err1 := strconv.ParseFloat(strings.ReplaceAll(fields[1], ",", ""), 64)
+		used, err2 := strconv.ParseFloat(strings.ReplaceAll(fields[2], ",", ""), 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		allocs[fields[0]] = QuotaInfo{
+			ProjectCode: fields[0],
+			Path:        fields[0],
+			Type:        "compute",
+			Tool:        "allocation-command",
+			Used:        used,
+			Limit:       limit,
+			Unit:        "core-hours",
+		}
+	}
+	return allocs
+}
+
+// ParseQstatF parses `qstat -f` and returns core-hours consumed so far by
+// running jobs, keyed by Account_Name. Jobs that started before periodStart
+// only count the part of their runtime inside the period.
+func ParseQstatF(out []byte, periodStart time.Time) map[string]float64 {
+	charges := make(map[string]float64)
+	var account, state string
+	var ncpus, hours float64
+	var started time.Time
+
+	flush := func() {
+		if account != "" && state == "R" {
+			charges[account] += ncpus * hoursInPeriod(hours, started, periodStart)
+		}
+		account, state, ncpus, hours, started = "", "", 0, 0, time.Time{}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Job Id:") {
+			flush()
+			continue
+		}
+		key, val, ok := strings.Cut(strings.TrimSpace(line), " = ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Account_Name":
+			account = val
+		case "job_state":
+			state = val
+		case "Resource_List.ncpus":
+			ncpus, _ = strconv.ParseFloat(val, 64)
+		case "resources_used.walltime":
+			hours = parseWalltime(val)
+		case "stime":
+			// qstat prints the start time in the server's local time.
+			started, _ = time.ParseInLocation("Mon Jan _2 15:04:05 2006", val, periodStart.Location())
+		}
+	}
+	flush()
+	return charges
+}
+
+// ReadPBSAccounting sums core-hours of finished jobs ("E" records) per project
+// from accounting logs dated on or after since, counting only the part of
+// each job's runtime after since.
+func ReadPBSAccounting(dir string, since time.Time) (map[string]float64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	first := since.Format("20060102")
+
+	charges := make(map[string]float64)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || len(name) != 8 || name < first {
+			continue
+		}
+		if _, err := strconv.Atoi(name); err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		for p, h := range ParsePBSAccounting(data, since) {
+			charges[p] += h
+		}
+	}
+	return charges, nil
+}
+
+// ParsePBSAccounting parses accounting records of the form
+// "MM/DD/YYYY HH:MM:SS;E;<jobid>;key=value ..." and sums ncpus × walltime of
+// job end records per project (account=, falling back to project=). Jobs
+// whose start= is before periodStart only count the part of their walltime
+// inside the period.
+func ParsePBSAccounting(data []byte, periodStart time.Time) map[string]float64 {
+	charges := make(map[string]float64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ";", 4)
+		if len(parts) < 4 || parts[1] != "E" {
+			continue
+		}
+		attrs := make(map[string]string)
+		for _, kv := range strings.Fields(parts[3]) {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				attrs[k] = strings.Trim(v, `"`)
+			}
+		}
+		project := attrs["account"]
+		if project == "" && attrs["project"] != "_pbs_project_default" {
+			project = attrs["project"]
+		}
+		if project == "" {
+			continue
+		}
+		ncpus, _ := strconv.ParseFloat(attrs["Resource_List.ncpus"], 64)
+		var started time.Time
+		if secs, err := strconv.ParseInt(attrs["start"], 10, 64); err == nil {
+			started = time.Unix(secs, 0)
+		}
+		charges[project] += ncpus * hoursInPeriod(parseWalltime(attrs["resources_used.walltime"]), started, periodStart)
+	}
+	return charges
+}
+
+// hoursInPeriod returns the part of a job's hours of runtime that falls after
+// periodStart, given when the job started (zero when unknown).
+func hoursInPeriod(hours float64, started, periodStart time.Time) float64 {
+	if started.IsZero() || !started.Before(periodStart) {
+		return hours
+	}
+	return max(hours-periodStart.Sub(started).Hours(), 0)
+}
+
+// parseWalltime converts "HH:MM:SS" (hours may exceed 24) into hours.
+func parseWalltime(s string) float64 {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0
+	}
+	var total float64
+	for i, unit := range []float64{3600, 60, 1} {
+		v, err := strconv.ParseFloat(parts[i], 64)
+		if err != nil {
+			return 0
+		}
+		total += v * unit
+	}
+	return total / 3600
+}
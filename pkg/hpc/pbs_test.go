@@ -0,0 +1,121 @@
+package hpc
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseQstatF(t *testing.T) {
+	charges := ParseQstatF(readFixture(t, "qstat_f.txt"), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	// Only the running job counts: 256 cores * 0.25h
+	if got := charges["UCB0001"]; got != 64 {
+		t.Errorf("Expected 64 core-hours from running jobs, got %.2f", got)
+	}
+}
+
+func TestReadPBSAccounting(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	charges, err := ReadPBSAccounting(filepath.Join("testdata", "pbs_accounting"), since)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 128*1h + 4*26h; the September log is outside the period.
+	if got := charges["UCB0001"]; got != 232 {
+		t.Errorf("Expected 232 core-hours for UCB0001, got %.2f", got)
+	}
+	// project= is used when account= is absent.
+	if got := charges["UCB0002"]; got != 128 {
+		t.Errorf("Expected 128 core-hours for UCB0002, got %.2f", got)
+	}
+}
+
+func TestCollectPBSFromAccounting(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{"qstat -f": "qstat_f.txt"}}
+	infos, err := CollectPBS(context.Background(), r, PBSConfig{
+		Projects:      []string{"UCB0001"},
+		AccountingDir: filepath.Join("testdata", "pbs_accounting"),
+		PeriodStart:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Used != 296 || infos[0].Unit != "core-hours" {
+		t.Fatalf("Expected 296 core-hours (232 finished + 64 running), got %+v", infos)
+	}
+	if infos[0].Limit != 0 {
+		t.Errorf("Expected no limit without pbs.allocations, got %v", infos[0].Limit)
+	}
+}
+
+func TestCollectPBSUsesConfiguredAllocations(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{"qstat -f": "qstat_f.txt"}}
+	infos, err := CollectPBS(context.Background(), r, PBSConfig{
+		Projects:      []string{"UCB0001", "UCB0002"},
+		AccountingDir: filepath.Join("testdata", "pbs_accounting"),
+		// Keys come back lowercased from the config file.
+		Allocations: map[string]float64{"ucb0001": 10000},
+		PeriodStart: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if infos[0].Limit != 10000 || infos[0].Used != 296 {
+		t.Errorf("Expected 296 of 10000 core-hours for UCB0001, got %+v", infos[0])
+	}
+	if infos[1].Limit != 0 {
+		t.Errorf("Expected no limit for UCB0002, got %v", infos[1].Limit)
+	}
+}
+
+func TestCollectPBSFromAllocationCommand(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{"site-alloc --user alice": "allocation.txt"}}
+	infos, err := CollectPBS(context.Background(), r, PBSConfig{AllocationCommand: "site-alloc --user alice"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 projects from the allocation command, got %d", len(infos))
+	}
+	if infos[0].ProjectCode != "UCB0001" || infos[0].Limit != 500000 || infos[0].Used != 123456.5 {
+		t.Errorf("Unexpected UCB0001 allocation: %+v", infos[0])
+	}
+	for _, c := range r.calls {
+		if c == "qstat -f" {
+			t.Error("qstat should not be consulted when the allocation command is authoritative")
+		}
+	}
+}
+
+func TestPBSChargesClipToPeriod(t *testing.T) {
+	periodStart := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	// Ran 22:00-02:00 across the period start: only 2 of its 4 hours count.
+	crossing := time.Date(2026, 9, 30, 22, 0, 0, 0, time.UTC).Unix()
+	// Ended before the period started, though logged in the first day's file.
+	before := time.Date(2026, 9, 30, 20, 0, 0, 0, time.UTC).Unix()
+	data := fmt.Sprintf("10/01/2026 02:00:00;E;4150.desched1;account=\"UCB0001\" Resource_List.ncpus=10 resources_used.walltime=04:00:00 start=%d\n"+
+		"10/01/2026 02:00:00;E;4151.desched1;account=\"UCB0002\" Resource_List.ncpus=10 resources_used.walltime=01:00:00 start=%d\n", crossing, before)
+	charges := ParsePBSAccounting([]byte(data), periodStart)
+	if got := charges["UCB0001"]; got != 20 {
+		t.Errorf("Expected 20 core-hours inside the period, got %.2f", got)
+	}
+	if got := charges["UCB0002"]; got != 0 {
+		t.Errorf("Expected a job that ended before the period to cost nothing, got %.2f", got)
+	}
+
+	// Running since 23:00 on the 30th with 3h of walltime: 2h are in the period.
+	qstat := "Job Id: 4500.desched1\n    job_state = R\n    Account_Name = UCB0001\n" +
+		"    Resource_List.ncpus = 10\n    resources_used.walltime = 03:00:00\n    stime = Wed Sep 30 23:00:00 2026\n"
+	if got := ParseQstatF([]byte(qstat), periodStart)["UCB0001"]; got != 20 {
+		t.Errorf("Expected 20 core-hours from the running job inside the period, got %.2f", got)
+	}
+}
+
+func TestCollectPBSRejectsBlankAllocationCommand(t *testing.T) {
+	_, err := CollectPBS(context.Background(), &fakeRunner{}, PBSConfig{AllocationCommand: "   "})
+	if err == nil {
+		t.Error("Expected an error for a blank allocation command")
+	}
+}
@@ -0,0 +1,193 @@
+package hpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlurmConfig selects which Slurm accounts to report and over which period.
+type SlurmConfig struct {
+	// Accounts to report; empty means every account the user has an association with.
+	Accounts []string
+	// User defaults to $USER (or the current process owner).
+	User string
+	// PeriodStart is the start of the allocation period; zero means the first
+	// day of the current month.
+	PeriodStart time.Time
+}
+
+// SlurmAllocation is the state of one Slurm account for the current period.
+type SlurmAllocation struct {
+	Account        string
+	CoreHours      float64 // consumed by all users of the account this period
+	GPUHours       float64
+	CoreHoursLimit float64 // from GrpTRESMins cpu=, 0 means no limit
+	GPUHoursLimit  float64 // from GrpTRESMins gres/gpu=, 0 means no limit
+	FairShare      float64 // the user's fairshare factor in this account (0..1)
+}
+
+// CollectSlurm combines sshare, sacctmgr and sacct output into one allocation per account.
+func CollectSlurm(ctx context.Context, r Runner, cfg SlurmConfig) ([]SlurmAllocation, error) {
+	user := cfg.User
+	if user == "" {
+		user = currentUser()
+	}
+	start := cfg.PeriodStart
+	if start.IsZero() {
+		now := time.Now()
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+
+	out, err := r.Run(ctx, "sshare", "-n", "-P", "-U", "-u", user, "-o", "Account,User,FairShare")
+	if err != nil {
+		return nil, err
+	}
+	fairshare := ParseSshare(out, user)
+
+	accounts := cfg.Accounts
+	if len(accounts) == 0 {
+		for acct := range fairshare {
+			accounts = append(accounts, acct)
+		}
+		sort.Strings(accounts)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no Slurm associations found for user %s", user)
+	}
+	acctList := strings.Join(accounts, ",")
+
+	out, err = r.Run(ctx, "sacctmgr", "-n", "-P", "show", "assoc", "where", "account="+acctList,
+		"format=Account,User,GrpTRESMins")
+	if err != nil {
+		return nil, err
+	}
+	limits := ParseSacctmgrAssoc(out, user)
+
+	out, err = r.Run(ctx, "sacct", "-n", "-P", "-X", "-a", "-A", acctList,
+		"-S", start.Format("2006-01-02T15:04:05"), "-E", "now", "-o", "Account,Start,ElapsedRaw,AllocTRES")
+	if err != nil {
+		return nil, err
+	}
+	usage := ParseSacct(out, start)
+
+	allocs := make([]SlurmAllocation, 0, len(accounts))
+	for _, acct := range accounts {
+		a := SlurmAllocation{Account: acct, FairShare: fairshare[acct]}
+		if u, ok := usage[acct]; ok {
+			a.CoreHours, a.GPUHours = u.CoreHours, u.GPUHours
+		}
+		if l, ok := limits[acct]; ok {
+			a.CoreHoursLimit, a.GPUHoursLimit = l.CoreHoursLimit, l.GPUHoursLimit
+		}
+		allocs = append(allocs, a)
+	}
+	return allocs, nil
+}
+
+// ParseSshare parses `sshare -n -P -o Account,User,FairShare` and returns the
+// fairshare factor of user in each account.
+func ParseSshare(out []byte, user string) map[string]float64 {
+	shares := make(map[string]float64)
+	eachRecord(out, func(f []string) {
+		if len(f) < 3 || f[1] != user {
+			return
+		}
+		if v, err := strconv.ParseFloat(f[2], 64); err == nil {
+			shares[f[0]] = v
+		}
+	})
+	return shares
+}
+
+// ParseSacctmgrAssoc parses `sacctmgr -n -P show assoc format=Account,User,GrpTRESMins`.
+// The account-level association (empty User) carries the allocation; a limit
+// on the user's own association is used when the account has none.
+func ParseSacctmgrAssoc(out []byte, user string) map[string]SlurmAllocation {
+	limits := make(map[string]SlurmAllocation)
+	eachRecord(out, func(f []string) {
+		if len(f) < 3 || (f[1] != "" && f[1] != user) {
+			return
+		}
+		tres := parseTRES(f[2])
+		if len(tres) == 0 {
+			return
+		}
+		if _, seen := limits[f[0]]; seen && f[1] != "" {
+			return // account-level limit wins
+		}
+		limits[f[0]] = SlurmAllocation{
+			Account:        f[0],
+			CoreHoursLimit: tres["cpu"] / 60,
+			GPUHoursLimit:  tres["gres/gpu"] / 60,
+		}
+	})
+	return limits
+}
+
+// ParseSacct parses `sacct -n -P -X -o Account,Start,ElapsedRaw,AllocTRES` and
+// sums core-hours and GPU-hours per account. Jobs that started before
+// periodStart only count the part of their runtime inside the period.
+func ParseSacct(out []byte, periodStart time.Time) map[string]SlurmAllocation {
+	usage := make(map[string]SlurmAllocation)
+	eachRecord(out, func(f []string) {
+		if len(f) < 4 {
+			return
+		}
+		elapsed, err := strconv.ParseFloat(f[2], 64)
+		if err != nil {
+			return
+		}
+		// sacct prints Start in local time, as given to -S.
+		if start, err := time.ParseInLocation("2006-01-02T15:04:05", f[1], periodStart.Location()); err == nil && start.Before(periodStart) {
+			elapsed = max(elapsed-periodStart.Sub(start).Seconds(), 0)
+		}
+		tres := parseTRES(f[3])
+		a := usage[f[0]]
+		a.Account = f[0]
+		a.CoreHours += tres["cpu"] * elapsed / 3600
+		a.GPUHours += tres["gres/gpu"] * elapsed / 3600
+		usage[f[0]] = a
+	})
+	return usage
+}
+
+// parseTRES parses a TRES string such as "cpu=4,gres/gpu=1,mem=16G".
+// Typed GRES entries (gres/gpu:a100=2) are folded into their base type.
+func parseTRES(s string) map[string]float64 {
+	tres := make(map[string]float64)
+	for _, part := range strings.Split(s, ",") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		if base, _, typed := strings.Cut(key, ":"); typed {
+			key = base
+		}
+		if v, ok := parseSize(val, 1); ok {
+			tres[key] += v
+		}
+	}
+	return tres
+}
+
+// eachRecord calls fn with the trimmed fields of every non-empty "|"-separated line.
+func eachRecord(out []byte, fn func([]string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		fn(fields)
+	}
+}
@@ -0,0 +1,58 @@
+package hpc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseSacct(t *testing.T) {
+	usage := ParseSacct(readFixture(t, "sacct.txt"), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+	// 128 cores * 1h + 64 cores * 2h
+	if got := usage["ucb123"].CoreHours; got != 256 {
+		t.Errorf("Expected 256 core-hours for ucb123, got %.2f", got)
+	}
+	// 4 typed GPUs (gres/gpu:a100) * 2h
+	if got := usage["ucb123"].GPUHours; got != 8 {
+		t.Errorf("Expected 8 GPU-hours for ucb123, got %.2f", got)
+	}
+	// 8 cores * 0.5h, plus 8 cores * 1h of a 3h job that started 2h before the period
+	if got := usage["ucb456"].CoreHours; got != 12 {
+		t.Errorf("Expected 12 core-hours for ucb456, got %.2f", got)
+	}
+}
+
+func TestParseSacctmgrAssoc(t *testing.T) {
+	limits := ParseSacctmgrAssoc(readFixture(t, "sacctmgr_assoc.txt"), "alice")
+	if l := limits["ucb123"]; l.CoreHoursLimit != 100000 || l.GPUHoursLimit != 1000 {
+		t.Errorf("Expected account-level limits 100000/1000 h, got %+v", l)
+	}
+	// No account-level limit: the user's own GrpTRESMins applies.
+	if l := limits["ucb456"]; l.CoreHoursLimit != 2000 {
+		t.Errorf("Expected user-level limit of 2000 h, got %+v", l)
+	}
+}
+
+func TestCollectSlurm(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	r := &fakeRunner{outputs: map[string]string{
+		"sshare -n -P -U -u alice -o Account,User,FairShare":                                                     "sshare.txt",
+		"sacctmgr -n -P show assoc where account=ucb123,ucb456 format=Account,User,GrpTRESMins":                  "sacctmgr_assoc.txt",
+		"sacct -n -P -X -a -A ucb123,ucb456 -S 2026-10-01T00:00:00 -E now -o Account,Start,ElapsedRaw,AllocTRES": "sacct.txt",
+	}}
+
+	allocs, err := CollectSlurm(context.Background(), r, SlurmConfig{User: "alice", PeriodStart: start})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(allocs) != 2 {
+		t.Fatalf("Expected 2 allocations, got %d", len(allocs))
+	}
+	a := allocs[0]
+	if a.Account != "ucb123" || a.CoreHours != 256 || a.CoreHoursLimit != 100000 || a.FairShare != 0.532143 {
+		t.Errorf("Unexpected ucb123 allocation: %+v", a)
+	}
+	if allocs[1].FairShare != 0.081 {
+		t.Errorf("Expected alice's fairshare in ucb456, got %v", allocs[1].FairShare)
+	}
+}
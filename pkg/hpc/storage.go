@@ -0,0 +1,417 @@
+package hpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	osuser "os/user"
+	"strconv"
+	"strings"
+)
+
+// QuotaInfo describes one quota (storage or compute) as reported by the cluster.
+type QuotaInfo struct {
+	ProjectCode string  // user, group or project the quota is charged to
+	Path        string  // filesystem, mount point or allocation the quota applies to
+	Type        string  // e.g. "storage", "compute"
+	Tool        string  // command the data came from (lfs, mmlsquota, quota, ...)
+	Used        float64 // in Unit
+	Limit       float64 // in Unit; 0 means no limit
+	Unit        string  // "bytes", "core-hours", ...
+	Grace       string  // remaining grace period when over the soft limit, "" if none
+	Files       int64   // inodes used (storage only)
+	FileLimit   int64   // inode limit (storage only), 0 means no limit
+}
+
+// Storage tool identifiers accepted in StorageConfig.Tool.
+const (
+	ToolAuto  = "auto"
+	ToolLFS   = "lfs"
+	ToolGPFS  = "gpfs"
+	ToolQuota = "quota"
+)
+
+// StorageConfig selects which filesystems and projects to query.
+type StorageConfig struct {
+	// Paths are Lustre mount points or GPFS device names.
+	Paths []string
+	// Projects are group/project names whose quotas are reported alongside the user's.
+	Projects []string
+	// Tool forces a specific backend; ToolAuto (or "") tries each in turn.
+	Tool string
+	// User defaults to $USER (or the current process owner).
+	User string
+}
+
+// Configured reports whether any storage target was configured.
+func (c StorageConfig) Configured() bool {
+	return len(c.Paths) > 0 || len(c.Projects) > 0 || (c.Tool != "" && c.Tool != ToolAuto)
+}
+
+// CollectStorage queries the configured filesystems and returns one QuotaInfo
+// per user/project and filesystem combination.
+func CollectStorage(ctx context.Context, r Runner, cfg StorageConfig) ([]QuotaInfo, error) {
+	user := cfg.User
+	if user == "" {
+		user = currentUser()
+	}
+
+	tools := []string{cfg.Tool}
+	if cfg.Tool == "" || cfg.Tool == ToolAuto {
+		tools = []string{ToolLFS, ToolGPFS, ToolQuota}
+	}
+
+	var errs []string
+	for _, tool := range tools {
+		infos, err := collectWith(ctx, r, tool, user, cfg)
+		if err == nil && len(infos) > 0 {
+			return infos, nil
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no storage quotas reported")
+	}
+	return nil, fmt.Errorf("no storage quotas reported: %s", strings.Join(errs, "; "))
+}
+
+func collectWith(ctx context.Context, r Runner, tool, user string, cfg StorageConfig) ([]QuotaInfo, error) {
+	var infos []QuotaInfo
+	switch tool {
+	case ToolLFS:
+		for _, path := range cfg.Paths {
+			out, err := r.Run(ctx, "lfs", "quota", "-u", user, path)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, ParseLFSQuota(out, user)...)
+			for _, proj := range cfg.Projects {
+				out, err := r.Run(ctx, "lfs", "quota", "-g", proj, path)
+				if err != nil {
+					return nil, err
+				}
+				infos = append(infos, ParseLFSQuota(out, proj)...)
+			}
+		}
+
+	case ToolGPFS:
+		owners := append([]string{user}, cfg.Projects...)
+		for i, owner := range owners {
+			flag := "-g"
+			if i == 0 {
+				flag = "-u"
+			}
+			args := append([]string{flag, owner}, cfg.Paths...)
+			out, err := r.Run(ctx, "mmlsquota", args...)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, ParseMMLSQuota(out, owner)...)
+		}
+
+	case ToolQuota:
+		// quota exits non-zero when a filesystem is over quota, so parse whatever it printed.
+		out, err := r.Run(ctx, "quota", "-s", "-u", user)
+		infos = ParseQuotaS(out, user)
+		if err != nil && len(infos) == 0 {
+			return nil, err
+		}
+		for _, proj := range cfg.Projects {
+			out, err := r.Run(ctx, "quota", "-s", "-g", proj)
+			parsed := ParseQuotaS(out, proj)
+			if err != nil && len(parsed) == 0 {
+				return nil, err
+			}
+			infos = append(infos, parsed...)
+		}
+		infos = filterPaths(infos, cfg.Paths)
+
+	default:
+		return nil, fmt.Errorf("unknown storage tool %q", tool)
+	}
+	return infos, nil
+}
+
+// ParseLFSQuota parses the output of `lfs quota -u|-g <name> <path>`.
+// Sizes are reported in kilobytes; a trailing "*" marks an exceeded quota.
+// Long filesystem names are printed on their own line, with the numbers on the next.
+func ParseLFSQuota(out []byte, owner string) []QuotaInfo {
+	var infos []QuotaInfo
+	var pending string // filesystem name waiting for its values on the next line
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "Disk" || fields[0] == "Filesystem" {
+			continue
+		}
+		if pending == "" && len(fields) == 1 {
+			pending = fields[0]
+			continue
+		}
+		if pending != "" {
+			fields = append([]string{pending}, fields...)
+			pending = ""
+		}
+		// Filesystem kbytes quota limit grace files quota limit grace
+		if len(fields) < 9 {
+			continue
+		}
+		used, ok := parseSize(fields[1], 1024)
+		if !ok {
+			continue
+		}
+		soft, _ := parseSize(fields[2], 1024)
+		hard, _ := parseSize(fields[3], 1024)
+		files, _ := parseCount(fields[5])
+		fsoft, _ := parseCount(fields[6])
+		fhard, _ := parseCount(fields[7])
+
+		infos = append(infos, QuotaInfo{
+			ProjectCode: owner,
+			Path:        fields[0],
+			Type:        "storage",
+			Tool:        ToolLFS,
+			Used:        used,
+			Limit:       effectiveLimit(soft, hard),
+			Unit:        "bytes",
+			Grace:       graceOrEmpty(fields[4], fields[8]),
+			Files:       files,
+			FileLimit:   int64(effectiveLimit(float64(fsoft), float64(fhard))),
+		})
+	}
+	return infos
+}
+
+// ParseMMLSQuota parses the output of GPFS `mmlsquota`. Block values are in
+// kilobytes unless they carry a unit suffix (--block-size auto). Grace periods
+// may contain spaces ("6 days"), so columns are located relative to the
+// quota type (USR, GRP, FILESET) and the "|" separator.
+func ParseMMLSQuota(out []byte, owner string) []QuotaInfo {
+	var infos []QuotaInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		left, right, found := strings.Cut(line, "|")
+		if !found {
+			continue
+		}
+		lf, rf := strings.Fields(left), strings.Fields(right)
+
+		typeIdx := -1
+		for i, f := range lf {
+			if f == "USR" || f == "GRP" || f == "FILESET" {
+				typeIdx = i
+				break
+			}
+		}
+		// type blocks quota limit in_doubt grace...
+		if typeIdx < 1 || len(lf) < typeIdx+6 || len(rf) < 5 {
+			continue
+		}
+		path := lf[0]
+		if typeIdx == 2 {
+			path = lf[0] + "/" + lf[1] // filesystem + fileset
+		}
+		vals := lf[typeIdx+1:]
+		used, ok := parseSize(vals[0], 1024)
+		if !ok {
+			continue
+		}
+		soft, _ := parseSize(vals[1], 1024)
+		hard, _ := parseSize(vals[2], 1024)
+		files, _ := parseCount(rf[0])
+		fsoft, _ := parseCount(rf[1])
+		fhard, _ := parseCount(rf[2])
+
+		infos = append(infos, QuotaInfo{
+			ProjectCode: owner,
+			Path:        path,
+			Type:        "storage",
+			Tool:        "mmlsquota",
+			Used:        used,
+			Limit:       effectiveLimit(soft, hard),
+			Unit:        "bytes",
+			Grace:       graceOrEmpty(strings.Join(vals[4:], " "), strings.Join(rf[4:], " ")),
+			Files:       files,
+			FileLimit:   int64(effectiveLimit(float64(fsoft), float64(fhard))),
+		})
+	}
+	return infos
+}
+
+// ParseQuotaS parses the output of `quota -s` from the Linux quota tools.
+// The grace columns are blank unless a soft limit is exceeded, so they are
+// detected by content rather than position.
+func ParseQuotaS(out []byte, owner string) []QuotaInfo {
+	var infos []QuotaInfo
+	var pending string
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] == "Disk" || fields[0] == "Filesystem" {
+			continue
+		}
+		if pending == "" && len(fields) == 1 {
+			pending = fields[0]
+			continue
+		}
+		if pending != "" {
+			fields = append([]string{pending}, fields...)
+			pending = ""
+		}
+		if len(fields) < 7 {
+			continue
+		}
+
+		fs, rest := fields[0], fields[1:]
+		used, ok := parseSize(rest[0], 1024)
+		if !ok {
+			continue
+		}
+		soft, _ := parseSize(rest[1], 1024)
+		hard, _ := parseSize(rest[2], 1024)
+		rest = rest[3:]
+
+		var blockGrace, fileGrace string
+		if len(rest) > 0 && isGrace(rest[0]) {
+			blockGrace, rest = rest[0], rest[1:]
+		}
+		if len(rest) < 3 {
+			continue
+		}
+		files, _ := parseCount(rest[0])
+		fsoft, _ := parseCount(rest[1])
+		fhard, _ := parseCount(rest[2])
+		if len(rest) > 3 && isGrace(rest[3]) {
+			fileGrace = rest[3]
+		}
+
+		infos = append(infos, QuotaInfo{
+			ProjectCode: owner,
+			Path:        fs,
+			Type:        "storage",
+			Tool:        ToolQuota,
+			Used:        used,
+			Limit:       effectiveLimit(soft, hard),
+			Unit:        "bytes",
+			Grace:       graceOrEmpty(blockGrace, fileGrace),
+			Files:       files,
+			FileLimit:   int64(effectiveLimit(float64(fsoft), float64(fhard))),
+		})
+	}
+	return infos
+}
+
+// currentUser returns $USER, falling back to the account of the running process.
+func currentUser() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	if u, err := osuser.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// filterPaths keeps only quotas whose filesystem contains one of paths.
+// An empty filter keeps everything.
+func filterPaths(infos []QuotaInfo, paths []string) []QuotaInfo {
+	if len(paths) == 0 {
+		return infos
+	}
+	var out []QuotaInfo
+	for _, q := range infos {
+		for _, p := range paths {
+			if strings.Contains(q.Path, p) {
+				out = append(out, q)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// effectiveLimit is the soft limit when one is set (it is what starts the
+// grace period), otherwise the hard limit.
+func effectiveLimit(soft, hard float64) float64 {
+	if soft > 0 {
+		return soft
+	}
+	return hard
+}
+
+// sizeUnits maps binary size suffixes to their multiplier in bytes.
+var sizeUnits = map[byte]float64{'K': 1 << 10, 'M': 1 << 20, 'G': 1 << 30, 'T': 1 << 40, 'P': 1 << 50}
+
+// parseSize converts a size such as "1234", "1234*", "5.2G" or "500M" to bytes.
+// Plain numbers are multiplied by baseUnit (1024 for kilobyte columns).
+func parseSize(s string, baseUnit float64) (float64, bool) {
+	s = strings.TrimSuffix(s, "*")
+	if s == "" {
+		return 0, false
+	}
+	mult := baseUnit
+	if m, ok := sizeUnits[strings.ToUpper(s[len(s)-1:])[0]]; ok {
+		mult, s = m, s[:len(s)-1]
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * mult, true
+}
+
+// parseCount converts an inode count such as "1234", "12k" or "3m".
+func parseCount(s string) (int64, bool) {
+	s = strings.TrimSuffix(s, "*")
+	if s == "" {
+		return 0, false
+	}
+	mult := 1.0
+	switch s[len(s)-1] {
+	case 'k', 'K':
+		mult, s = 1e3, s[:len(s)-1]
+	case 'm', 'M':
+		mult, s = 1e6, s[:len(s)-1]
+	case 'g', 'G':
+		mult, s = 1e9, s[:len(s)-1]
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(v * mult), true
+}
+
+// isGrace reports whether a token is a grace period ("6days", "23:59", "none")
+// rather than a size or count.
+func isGrace(s string) bool {
+	if s == "none" || s == "-" || strings.Contains(s, ":") {
+		return true
+	}
+	for _, unit := range []string{"day", "hour", "min", "sec", "week", "expired"} {
+		if strings.Contains(s, unit) {
+			return true
+		}
+	}
+	return false
+}
+
+// graceOrEmpty returns the first meaningful grace value.
+func graceOrEmpty(values ...string) string {
+	for _, v := range values {
+		switch strings.TrimSpace(v) {
+		case "", "-", "none", "0":
+			continue
+		default:
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
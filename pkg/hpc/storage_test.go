@@ -0,0 +1,135 @@
+package hpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRunner replays captured command output keyed by the full command line.
+type fakeRunner struct {
+	outputs map[string]string // command line -> fixture file in testdata/
+	calls   []string
+}
+
+func (f *fakeRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	f.calls = append(f.calls, line)
+	fixture, ok := f.outputs[line]
+	if !ok {
+		return nil, fmt.Errorf("%s: command not found", name)
+	}
+	return os.ReadFile(filepath.Join("testdata", fixture))
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+const gib = 1 << 30
+
+func TestParseLFSQuota(t *testing.T) {
+	infos := ParseLFSQuota(readFixture(t, "lfs_quota_user.txt"), "alice")
+	if len(infos) != 1 {
+		t.Fatalf("Expected 1 quota, got %d", len(infos))
+	}
+	q := infos[0]
+	if q.Path != "/glade/derecho/scratch" {
+		t.Errorf("Expected wrapped filesystem name, got %q", q.Path)
+	}
+	if q.Used != 1536*gib || q.Limit != 1024*gib {
+		t.Errorf("Expected 1536/1024 GiB, got %.0f/%.0f", q.Used/gib, q.Limit/gib)
+	}
+	if q.Grace != "6d23h59m" {
+		t.Errorf("Expected grace 6d23h59m, got %q", q.Grace)
+	}
+	if q.Files != 120034 || q.FileLimit != 0 {
+		t.Errorf("Expected 120034 files with no limit, got %d/%d", q.Files, q.FileLimit)
+	}
+
+	// Only a hard limit is set: it becomes the effective limit.
+	infos = ParseLFSQuota(readFixture(t, "lfs_quota_group.txt"), "ucb123")
+	if len(infos) != 1 || infos[0].Limit != 100*gib || infos[0].FileLimit != 500000 {
+		t.Fatalf("Unexpected group quota: %+v", infos)
+	}
+}
+
+func TestParseMMLSQuota(t *testing.T) {
+	infos := ParseMMLSQuota(readFixture(t, "mmlsquota.txt"), "alice")
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 quotas, got %d", len(infos))
+	}
+	if infos[0].Path != "glade" || infos[0].Used != 10*gib || infos[0].Limit != 50*gib || infos[0].Grace != "" {
+		t.Errorf("Unexpected first quota: %+v", infos[0])
+	}
+	if infos[0].Files != 123456 || infos[0].FileLimit != 1000000 {
+		t.Errorf("Unexpected file counts: %d/%d", infos[0].Files, infos[0].FileLimit)
+	}
+	if infos[1].Grace != "6 days" {
+		t.Errorf("Expected grace with a space to survive, got %q", infos[1].Grace)
+	}
+}
+
+func TestParseQuotaS(t *testing.T) {
+	infos := ParseQuotaS(readFixture(t, "quota_s.txt"), "alice")
+	if len(infos) != 2 {
+		t.Fatalf("Expected 2 quotas, got %d", len(infos))
+	}
+	home := infos[0]
+	if home.Path != "/dev/mapper/vg-home" || home.Used != 4200*(1<<20) || home.Limit != 5000*(1<<20) {
+		t.Errorf("Unexpected home quota: %+v", home)
+	}
+	if home.Grace != "" || home.Files != 120000 {
+		t.Errorf("Expected blank grace and 120k files, got %q / %d", home.Grace, home.Files)
+	}
+	proj := infos[1]
+	if proj.Used != 52*gib || proj.Grace != "6days" || proj.FileLimit != 100000 {
+		t.Errorf("Unexpected project quota: %+v", proj)
+	}
+}
+
+func TestCollectStorageAutoFallsBack(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"mmlsquota -u alice glade campaign": "mmlsquota.txt",
+	}}
+	infos, err := CollectStorage(context.Background(), r, StorageConfig{
+		Paths: []string{"glade", "campaign"},
+		User:  "alice",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(infos) != 2 || infos[0].Tool != "mmlsquota" {
+		t.Fatalf("Expected GPFS quotas after lfs failed, got %+v", infos)
+	}
+	if r.calls[0] != "lfs quota -u alice glade" {
+		t.Errorf("Expected lfs to be tried first, got %q", r.calls[0])
+	}
+}
+
+func TestCollectStorageProjects(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"lfs quota -u alice /glade/work":  "lfs_quota_user.txt",
+		"lfs quota -g ucb123 /glade/work": "lfs_quota_group.txt",
+	}}
+	infos, err := CollectStorage(context.Background(), r, StorageConfig{
+		Paths:    []string{"/glade/work"},
+		Projects: []string{"ucb123"},
+		Tool:     ToolLFS,
+		User:     "alice",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(infos) != 2 || infos[1].ProjectCode != "ucb123" {
+		t.Fatalf("Expected user and project quotas, got %+v", infos)
+	}
+}
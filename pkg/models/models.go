@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // ProviderType categorizes how the provider bills its usage.
 type ProviderType string
 
@@ -11,29 +13,72 @@ const (
 
 // ProviderReport contains all unified metrics for a single provider.
 type ProviderReport struct {
+	// ID is the provider's stable identifier, e.g. "copilot"; Name may be
+	// replaced by a configured display name.
+	ID   string       `json:"id"`
 	Name string       `json:"name"`
 	Type ProviderType `json:"type"`
 
 	// Quota-based metrics
-	Remaining        int    `json:"remaining,omitempty"`
-	Entitlement      int    `json:"entitlement,omitempty"`
-	UsagePercentage  int    `json:"usagePercentage,omitempty"`
-	OveragePermitted bool   `json:"overagePermitted,omitempty"`
-	RefreshTime      string `json:"refreshTime,omitempty"`
+	Remaining        int  `json:"remaining,omitempty"`
+	Entitlement      int  `json:"entitlement,omitempty"`
+	UsagePercentage  int  `json:"usagePercentage,omitempty"`
+	OveragePermitted bool `json:"overagePermitted,omitempty"`
+	// Unit of Remaining, Entitlement and usage history when they are not
+	// requests or tokens, e.g. UnitPercent for quotas reported as a percentage.
+	Unit string `json:"unit,omitempty"`
+	// ResetAt is when the current quota window resets and WindowStart when it
+	// began. Both are nil when the provider does not report them.
+	ResetAt     *time.Time `json:"resetAt,omitempty"`
+	WindowStart *time.Time `json:"windowStart,omitempty"`
+	// WindowKind says how often the quota resets (WindowWeekly, WindowMonthly, ...).
+	WindowKind string `json:"windowKind,omitempty"`
+	// Plan is the subscription plan reported by the provider (e.g. Copilot "business").
+	Plan string `json:"plan,omitempty"`
+	// Threshold is the usage percentage at which the provider is flagged (0 = never).
+	Threshold int `json:"threshold,omitempty"`
+
+	// Exhaustion estimates when the remaining quota runs out at the current burn rate.
+	Exhaustion *Exhaustion `json:"exhaustion,omitempty"`
 
 	// Error state (non-fatal: provider was found but fetch failed)
 	ErrorMsg string `json:"error,omitempty"`
 
-	// Pay-As-You-Go metrics
+	// CachedAt is set when the report was served from the local cache, and is
+	// when it was fetched.
+	CachedAt *time.Time `json:"cachedAt,omitempty"`
+	// Stale marks a cached report shown because the live fetch failed.
+	Stale bool `json:"stale,omitempty"`
+	// StaleReason is the error of the failed fetch behind a Stale report.
+	StaleReason string `json:"staleReason,omitempty"`
+
+	// Pay-As-You-Go metrics. Cost is the spend counter the provider reports,
+	// which may be lifetime (OpenRouter) or month-to-date (OpenCode Zen).
 	Cost float64 `json:"cost,omitempty"`
+	// Credits is the total prepaid credit purchased, when the provider reports it.
+	Credits float64 `json:"credits,omitempty"`
+	// MonthToDate is the spend since the start of the calendar month.
+	MonthToDate float64 `json:"monthToDate,omitempty"`
+	// Budget tracks this month's spend against the provider's configured budget.
+	Budget *BudgetStatus `json:"budget,omitempty"`
 
 	// Tokens-based metrics
 	TokensUsed int64 `json:"tokensUsed,omitempty"`
 
+	// Windows lists every concurrent quota window (e.g. Claude's 5-hour and
+	// 7-day limits) when the provider has more than one. UsagePercentage,
+	// Remaining, Entitlement and ResetAt above describe the binding window.
+	Windows []QuotaWindow `json:"windows,omitempty"`
+
+	// Models breaks the quota down per model when the provider has separate
+	// buckets (e.g. Gemini Pro and Flash).
+	Models []ModelQuota `json:"models,omitempty"`
+
 	// Multiple accounts (e.g. Gemini CLI)
 	Accounts []Account `json:"accounts,omitempty"`
 
-	// History and Prediction (New)
+	// History is the daily usage, oldest first, and Prediction the forecast
+	// built from it (qcli report and forecast only).
 	History    []DailyUsage      `json:"history,omitempty"`
 	Prediction *PredictionReport `json:"prediction,omitempty"`
 }
@@ -47,9 +92,54 @@ type DailyUsage struct {
 
 // PredictionReport holds the forecasted usage metrics.
 type PredictionReport struct {
+	// PredictedMonthlyRequests is the usage forecast at the end of the quota
+	// window: at ResetAt when known, otherwise at the end of the calendar month.
 	PredictedMonthlyRequests float64 `json:"predictedMonthlyRequests"`
 	PredictedExtraCost       float64 `json:"predictedExtraCost"`
-	Confidence               string  `json:"confidence"` // Low, Medium, High
+	// Intervals bound the prediction at 80% and 95% coverage.
+	Intervals []PredictionInterval `json:"intervals,omitempty"`
+	// Confidence is derived from the width of the 95% interval: High, Medium or Low.
+	Confidence string `json:"confidence"`
+	// Model is the forecasting model that produced the prediction.
+	Model string `json:"model,omitempty"`
+	// Price is the unit price PredictedExtraCost was computed with, if any.
+	Price *Price `json:"price,omitempty"`
+	// ResetAt is the provider's reset instant the forecast targets, and
+	// WindowDays the length of its quota window; both unset for calendar months.
+	ResetAt    *time.Time `json:"resetAt,omitempty"`
+	WindowDays float64    `json:"windowDays,omitempty"`
+}
+
+// PredictionInterval is the range usage and cost are expected to fall in with
+// the given probability (in percent).
+type PredictionInterval struct {
+	Level        int     `json:"level"`
+	RequestsLow  float64 `json:"requestsLow"`
+	RequestsHigh float64 `json:"requestsHigh"`
+	CostLow      float64 `json:"costLow,omitempty"`
+	CostHigh     float64 `json:"costHigh,omitempty"`
+}
+
+// Price units.
+const (
+	UnitRequest       = "request"
+	UnitMillionTokens = "1M tokens"
+)
+
+// Price is the cost in USD of one unit of usage and where that figure came from.
+type Price struct {
+	PerUnit float64 `json:"perUnit"`
+	Unit    string  `json:"unit"`
+	Plan    string  `json:"plan,omitempty"`
+	Source  string  `json:"source"` // "built-in" or the config key that set it
+}
+
+// Cost returns the price of amount units of usage (requests or tokens).
+func (p Price) Cost(amount float64) float64 {
+	if p.Unit == UnitMillionTokens {
+		amount /= 1e6
+	}
+	return amount * p.PerUnit
 }
 
 // Account holds metadata for providers with multiple local credentials.
@@ -61,6 +151,79 @@ type Account struct {
 	Entitlement         int            `json:"entitlement"`
 	RemainingPercentage int            `json:"remainingPercentage"`
 	ModelBreakdown      map[string]int `json:"modelBreakdown"`
+	// Unit of Remaining/Entitlement when they are not percentages or requests (e.g. "GiB").
+	Unit string `json:"unit,omitempty"`
+	// Note carries secondary details such as file counts or grace periods.
+	Note string `json:"note,omitempty"`
+	// ResetAt is when this account's quota resets, if known, and WindowKind
+	// how often it does.
+	ResetAt    *time.Time `json:"resetAt,omitempty"`
+	WindowKind string     `json:"windowKind,omitempty"`
+	// Windows lists the account's concurrent quota windows, if it has several.
+	Windows []QuotaWindow `json:"windows,omitempty"`
+	// Models breaks the account's quota down per model.
+	Models []ModelQuota `json:"models,omitempty"`
+	// Exhaustion estimates when this account's quota runs out.
+	Exhaustion *Exhaustion `json:"exhaustion,omitempty"`
+}
+
+// UnitPercent is the unit of quotas reported only as a percentage used.
+const UnitPercent = "%"
+
+// Window kinds. Windows of other lengths are named after them, e.g. "5-hour".
+const (
+	WindowDaily   = "daily"
+	WindowWeekly  = "weekly"
+	WindowMonthly = "monthly"
+)
+
+// QuotaWindow is one rate-limit window of a provider, such as a rolling
+// 5-hour or 7-day limit.
+type QuotaWindow struct {
+	Name        string  `json:"name"`
+	UsedPercent float64 `json:"usedPercent"`
+	// Limit is the window's allowance in requests when the provider reports it.
+	Limit         int        `json:"limit,omitempty"`
+	ResetAt       *time.Time `json:"resetAt,omitempty"`
+	WindowSeconds int64      `json:"windowSeconds,omitempty"`
+	// Binding marks the window that blocks usage first: the most used one,
+	// or among equally used ones the one that resets last.
+	Binding bool `json:"binding,omitempty"`
+}
+
+// ModelQuota is the quota bucket of one model and token type.
+type ModelQuota struct {
+	Model string `json:"model"`
+	// TokenType is what the bucket counts, e.g. "REQUESTS" or "INPUT_TOKENS".
+	TokenType         string     `json:"tokenType,omitempty"`
+	RemainingFraction float64    `json:"remainingFraction"`
+	ResetAt           *time.Time `json:"resetAt,omitempty"`
+}
+
+// Exhaustion is the estimated time a quota runs out.
+type Exhaustion struct {
+	At          time.Time `json:"at"`
+	BurnPerHour float64   `json:"burnPerHour"`
+	// BeforeReset is set when the quota runs out before its window resets.
+	BeforeReset bool `json:"beforeReset"`
+}
+
+// Budget states.
+const (
+	BudgetOnTrack = "on-track"
+	BudgetAtRisk  = "at-risk"
+	BudgetOver    = "over"
+)
+
+// BudgetStatus compares the spend so far this calendar month with a monthly
+// budget in USD. Limit is 0 (and Status empty) when no budget is set.
+type BudgetStatus struct {
+	Limit     float64 `json:"limit,omitempty"`
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining,omitempty"`
+	// Projected is the spend expected by month end at this month's burn rate.
+	Projected float64 `json:"projected"`
+	Status    string  `json:"status,omitempty"`
 }
 
 // OpenCodeAuthConfig models the structure of auth.json used by OpenCode.
@@ -71,79 +234,62 @@ type OpenCodeAuthConfig struct {
 	CopilotToken  string `json:"copilot.token,omitempty"`
 	// add other keys dynamically or explicitly as required.
 	RawKeys map[string]interface{} `json:"-"`
+	// Sources records where each top-level RawKeys entry was loaded from
+	// (e.g. "auth.json: /path/to/auth.json" or "env: GITHUB_TOKEN").
+	Sources map[string]string `json:"-"`
 }
 
-// GetKey attempts to find the corresponding API key for a provider name.
-func (cfg *OpenCodeAuthConfig) GetKey(providerName string) string {
-	if cfg.RawKeys == nil {
+// Source returns the provenance of a top-level RawKeys entry, or "" if unknown.
+func (cfg *OpenCodeAuthConfig) Source(rawKey string) string {
+	if cfg.Sources == nil {
 		return ""
 	}
+	return cfg.Sources[rawKey]
+}
 
-	// Try old flat format first
-	var flatKey string
-	switch providerName {
-	case "OpenRouter":
-		flatKey = "openrouter.key"
-	case "Claude":
-		flatKey = "claude.key"
-	case "Gemini CLI":
-		flatKey = "gemini.key"
-	case "OpenAI":
-		flatKey = "openai.key"
-	case "Vertex AI":
-		flatKey = "vertex.key"
-	case "Google AI Studio":
-		flatKey = "googleaistudio.key"
-	case "GitHub Copilot":
-		flatKey = "copilot.token"
+// KeyNames are the auth.json entries a provider's API key may be stored
+// under: a flat entry such as "openai.key", then nested objects such as
+// {"openai": {"key": "..."}}, tried in order.
+type KeyNames struct {
+	Flat   string
+	Nested []string
+}
+
+// nestedKeyFields are the fields of a nested entry that may hold the key.
+var nestedKeyFields = []string{"key", "access", "refresh", "token"}
+
+// GetKey returns the API key stored under any of names, or "".
+func (cfg *OpenCodeAuthConfig) GetKey(names KeyNames) string {
+	key, _ := cfg.LookupKey(names)
+	return key
+}
+
+// LookupKey is like GetKey but also returns the top-level RawKeys entry the
+// key was found under, so callers can report where it came from.
+func (cfg *OpenCodeAuthConfig) LookupKey(names KeyNames) (string, string) {
+	if cfg.RawKeys == nil {
+		return "", ""
 	}
 
-	if flatKey != "" {
-		if val := extractString(cfg.RawKeys, flatKey); val != "" {
-			return val
+	// Try old flat format first
+	if names.Flat != "" {
+		if val := extractString(cfg.RawKeys, names.Flat); val != "" {
+			return val, names.Flat
 		}
 	}
 
 	// Try nested structure (e.g., {"openai": {"key": "..."}})
-	var nestedKey string
-	switch providerName {
-	case "OpenRouter":
-		nestedKey = "openrouter"
-	case "Claude":
-		nestedKey = "claude"
-	case "Gemini CLI":
-		nestedKey = "gemini"
-	case "OpenAI":
-		nestedKey = "openai"
-	case "Vertex AI":
-		nestedKey = "vertex"
-	case "Google AI Studio":
-		nestedKey = "google" // Users often use "google" or "google-custom"
-	case "GitHub Copilot":
-		nestedKey = "github-copilot"
-	}
-
-	if nestedKey != "" {
-		if val, ok := cfg.RawKeys[nestedKey].(map[string]interface{}); ok {
-			// Check common fields in nested structure
-			for _, k := range []string{"key", "access", "refresh", "token"} {
+	for _, nested := range names.Nested {
+		if val, ok := cfg.RawKeys[nested].(map[string]interface{}); ok {
+			for _, k := range nestedKeyFields {
 				if s, ok := val[k].(string); ok && s != "" {
-					return s
+					return s, nested
 				}
 			}
 		}
 	}
 
-	// Extra fallback for Google custom keys
-	if providerName == "Google AI Studio" {
-		if val, ok := cfg.RawKeys["google-custom"].(map[string]interface{}); ok {
-			if s, ok := val["key"].(string); ok {
-				return s
-			}
-		}
-	}
-
-	return ""
+	return "", ""
 }
 
 // GetNestedField retrieves a specific field from a nested provider configuration.
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SchemaVersion is the version of the JSON document printed by --json. Bump it
+// whenever a field is added, renamed or removed, and regenerate the published
+// schema with 'go generate ./pkg/schema'.
+const SchemaVersion = 4
+
+// Output is the JSON document printed by qcli status, report and forecast.
+// The --json output of list, budget and forecast backtest is not wrapped in it.
+type Output struct {
+	// SchemaVersion identifies the layout of this document (see qcli schema).
+	SchemaVersion int `json:"schemaVersion"`
+	// GeneratedAt is when the reports were fetched, in UTC.
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Host is the name of the machine qcli ran on.
+	Host string `json:"host"`
+	// Reports holds one entry per provider, sorted by name.
+	Reports []*ProviderReport `json:"reports"`
+}
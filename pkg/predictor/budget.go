@@ -0,0 +1,46 @@
+package predictor
+
+import (
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// minBudgetElapsed keeps the first hours of a month from extrapolating a few
+// cents into a huge projection.
+const minBudgetElapsed = 24 * time.Hour
+
+// EvaluateBudget projects spent (so far this calendar month, in now's time
+// zone) to the end of the month at the month's average burn rate and compares
+// both with limit. A limit of 0 means no budget: only Spent and Projected are set.
+func EvaluateBudget(spent, limit float64, now time.Time) *models.BudgetStatus {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, 1, 0)
+	elapsed := now.Sub(start)
+	if elapsed < minBudgetElapsed {
+		elapsed = minBudgetElapsed
+	}
+
+	b := &models.BudgetStatus{
+		Limit:     limit,
+		Spent:     spent,
+		Projected: spent * float64(end.Sub(start)) / float64(elapsed),
+	}
+	if b.Projected < spent {
+		b.Projected = spent
+	}
+	if limit <= 0 {
+		return b
+	}
+
+	b.Remaining = max(limit-spent, 0)
+	switch {
+	case spent > limit:
+		b.Status = models.BudgetOver
+	case b.Projected > limit:
+		b.Status = models.BudgetAtRisk
+	default:
+		b.Status = models.BudgetOnTrack
+	}
+	return b
+}
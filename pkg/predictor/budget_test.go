@@ -0,0 +1,38 @@
+package predictor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestEvaluateBudget(t *testing.T) {
+	// Ten of September's thirty days have passed.
+	now := time.Date(2026, 9, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spent, limit  float64
+		wantProjected float64
+		wantStatus    string
+	}{
+		{spent: 10, limit: 50, wantProjected: 30, wantStatus: models.BudgetOnTrack},
+		{spent: 20, limit: 50, wantProjected: 60, wantStatus: models.BudgetAtRisk},
+		{spent: 55, limit: 50, wantProjected: 165, wantStatus: models.BudgetOver},
+		{spent: 10, limit: 0, wantProjected: 30, wantStatus: ""},
+	}
+	for _, tt := range tests {
+		got := EvaluateBudget(tt.spent, tt.limit, now)
+		if math.Abs(got.Projected-tt.wantProjected) > 1e-9 || got.Status != tt.wantStatus {
+			t.Errorf("EvaluateBudget(%v, %v) = projected %v, %q; want %v, %q",
+				tt.spent, tt.limit, got.Projected, got.Status, tt.wantProjected, tt.wantStatus)
+		}
+	}
+
+	// On the first morning of the month the projection uses at least a full day.
+	early := EvaluateBudget(1, 100, time.Date(2026, 9, 1, 2, 0, 0, 0, time.UTC))
+	if early.Projected != 30 || early.Status != models.BudgetOnTrack {
+		t.Errorf("early in the month: got %+v", early)
+	}
+}
@@ -0,0 +1,224 @@
+package predictor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// Calendar describes when the user works. The zero value means the local time
+// zone, a Monday–Friday week and no holidays.
+type Calendar struct {
+	// Location sets day boundaries; nil means time.Local.
+	Location *time.Location
+	// WorkDays are the working days of the week; empty means Monday–Friday.
+	WorkDays []time.Weekday
+	// Holidays are non-working dates formatted as YYYY-MM-DD.
+	Holidays map[string]bool
+}
+
+// Loc returns the calendar's time zone.
+func (c Calendar) Loc() *time.Location {
+	if c.Location == nil {
+		return time.Local
+	}
+	return c.Location
+}
+
+// IsWorkDay reports whether the calendar day of t is a working day.
+func (c Calendar) IsWorkDay(t time.Time) bool {
+	if c.Holidays[t.Format("2006-01-02")] {
+		return false
+	}
+	if len(c.WorkDays) == 0 {
+		return !isWeekend(t)
+	}
+	for _, d := range c.WorkDays {
+		if t.Weekday() == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize prepares daily history for forecasting: it sorts it oldest first,
+// merges duplicate dates (the later entry wins, as providers re-report days
+// that were still in progress) and fills days missing between the first and
+// last entry with zero usage. Entries with unparseable dates are dropped.
+func Normalize(history []models.DailyUsage, cal Calendar) []models.DailyUsage {
+	byDate := make(map[string]models.DailyUsage, len(history))
+	var first, last time.Time
+	for _, d := range history {
+		t, err := time.ParseInLocation("2006-01-02", d.Date, cal.Loc())
+		if err != nil {
+			continue
+		}
+		byDate[d.Date] = d
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	if len(byDate) == 0 {
+		return nil
+	}
+
+	var out []models.DailyUsage
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		d, ok := byDate[date]
+		if !ok {
+			d = models.DailyUsage{Date: date}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// ParseWeekdays parses weekday names such as "mon" or "Monday".
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range names {
+		d, ok := weekdayByPrefix(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func weekdayByPrefix(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), name) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// LoadHolidays reads holiday dates from an iCalendar (.ics) file or a YAML
+// list of dates (plain "YYYY-MM-DD" entries or {date, name} objects).
+func LoadHolidays(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".ics") {
+		return ParseICS(data)
+	}
+	return parseHolidayYAML(data)
+}
+
+func parseHolidayYAML(data []byte) (map[string]bool, error) {
+	var entries []yaml.Node
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("holidays must be a YAML list of dates: %w", err)
+	}
+	holidays := make(map[string]bool)
+	for _, n := range entries {
+		var date string
+		if n.Kind == yaml.MappingNode {
+			var entry struct {
+				Date string `yaml:"date"`
+			}
+			if err := n.Decode(&entry); err != nil {
+				return nil, err
+			}
+			date = entry.Date
+		} else {
+			date = n.Value
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("line %d: expected a date as YYYY-MM-DD, got %q", n.Line, date)
+		}
+		holidays[date] = true
+	}
+	return holidays, nil
+}
+
+// ParseICS returns the days covered by the VEVENTs of an iCalendar file. All-day
+// events cover DTSTART up to (but excluding) DTEND; timed events cover their start day.
+func ParseICS(data []byte) (map[string]bool, error) {
+	holidays := make(map[string]bool)
+	var start, end time.Time
+	inEvent := false
+
+	for _, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end = true, time.Time{}, time.Time{}
+			}
+		case "DTSTART":
+			if inEvent {
+				start = parseICSDate(value)
+			}
+		case "DTEND":
+			if inEvent {
+				end = parseICSDate(value)
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("VEVENT without a valid DTSTART")
+			}
+			holidays[start.Format("2006-01-02")] = true
+			for d := start.AddDate(0, 0, 1); d.Before(end); d = d.AddDate(0, 0, 1) {
+				holidays[d.Format("2006-01-02")] = true
+			}
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICS splits iCalendar content into logical lines, joining continuation
+// lines that start with a space or tab.
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseICSDate parses the date part of DATE (20261225) or DATE-TIME
+// (20261225T090000Z) values.
+func parseICSDate(value string) time.Time {
+	if len(value) < 8 {
+		return time.Time{}
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
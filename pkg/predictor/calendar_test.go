@@ -0,0 +1,75 @@
+package predictor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestNormalize(t *testing.T) {
+	history := []models.DailyUsage{
+		{Date: "2026-09-10", IncludedRequests: 5},
+		{Date: "2026-09-07", IncludedRequests: 1},
+		{Date: "2026-09-10", IncludedRequests: 7}, // re-reported, wins
+		{Date: "not a date", IncludedRequests: 99},
+	}
+	got := Normalize(history, Calendar{Location: time.UTC})
+
+	want := []models.DailyUsage{
+		{Date: "2026-09-07", IncludedRequests: 1},
+		{Date: "2026-09-08"},
+		{Date: "2026-09-09"},
+		{Date: "2026-09-10", IncludedRequests: 7},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d days, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261224\r\nDTEND;VALUE=DATE:20261226\r\nSUMMARY:Christmas\r\n break\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART:20261231T090000Z\r\nDTEND:20261231T170000Z\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	got, err := ParseICS([]byte(ics))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, date := range []string{"2026-12-24", "2026-12-25", "2026-12-31"} {
+		if !got[date] {
+			t.Errorf("%s missing from %v", date, got)
+		}
+	}
+	if got["2026-12-26"] {
+		t.Errorf("DTEND of an all-day event is exclusive, got %v", got)
+	}
+}
+
+func TestCalendarIsWorkDay(t *testing.T) {
+	days, err := ParseWeekdays([]string{"sun", "Monday", "tue", "wed", "thu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := Calendar{WorkDays: days, Holidays: map[string]bool{"2026-09-08": true}}
+
+	for date, want := range map[string]bool{
+		"2026-09-06": true,  // Sunday
+		"2026-09-07": true,  // Monday
+		"2026-09-08": false, // holiday
+		"2026-09-11": false, // Friday
+	} {
+		d, _ := time.Parse("2006-01-02", date)
+		if got := cal.IsWorkDay(d); got != want {
+			t.Errorf("IsWorkDay(%s) = %v, want %v", date, got, want)
+		}
+	}
+	if _, err := ParseWeekdays([]string{"mo"}); err == nil {
+		t.Error("ParseWeekdays accepted an ambiguous abbreviation")
+	}
+}
@@ -0,0 +1,107 @@
+package predictor
+
+import (
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+const (
+	// burnWindow is how far back BurnRate looks for snapshots.
+	burnWindow = 24 * time.Hour
+	// minBurnSpan is the shortest stretch of snapshots a rate is computed from.
+	minBurnSpan = 10 * time.Minute
+	// maxExhaustion is how far ahead an exhaustion time is still worth reporting.
+	maxExhaustion = 365 * 24 * time.Hour
+	// resetTolerance absorbs jitter in the reset times providers report, so
+	// only a window that really moved on counts as a reset.
+	resetTolerance = time.Minute
+)
+
+// Sample is one observation of a usage counter (quota used so far).
+type Sample struct {
+	At   time.Time
+	Used float64
+	// ResetAt and WindowStart bound the quota window the counter was in;
+	// nil when the provider does not report them.
+	ResetAt, WindowStart *time.Time
+}
+
+// ResetSince returns when the counter reset after prev: the previous reset
+// instant, once s was taken after it and the reset time moved forward, or the
+// new window's start when only that is known. It returns nil otherwise, in
+// which case a lower value is not a reset but usage leaving a rolling window
+// or, for storage, deleted files. Requiring the old reset instant to have
+// passed also ignores a provider switching which of its windows it reports.
+func (s Sample) ResetSince(prev Sample) *time.Time {
+	if prev.ResetAt != nil {
+		if !s.At.Before(*prev.ResetAt) && movedForward(prev.ResetAt, s.ResetAt) {
+			return prev.ResetAt
+		}
+		return nil
+	}
+	if movedForward(prev.WindowStart, s.WindowStart) {
+		return s.WindowStart
+	}
+	return nil
+}
+
+func movedForward(before, after *time.Time) bool {
+	return before != nil && after != nil && after.Sub(*before) > resetTolerance
+}
+
+// BurnRate returns usage per hour from samples taken over the last 24 hours,
+// oldest first. After a reset (see ResetSince) the new value counts as usage;
+// any other drop in the counter counts as none. It returns 0 when the samples
+// span less than ten minutes.
+func BurnRate(samples []Sample, now time.Time) float64 {
+	since := now.Add(-burnWindow)
+	first := 0
+	for first < len(samples)-1 && samples[first+1].At.Before(since) {
+		first++
+	}
+	samples = samples[first:]
+	if len(samples) < 2 {
+		return 0
+	}
+	span := samples[len(samples)-1].At.Sub(samples[0].At)
+	if span < minBurnSpan {
+		return 0
+	}
+
+	var used float64
+	for i := 1; i < len(samples); i++ {
+		if samples[i].ResetSince(samples[i-1]) != nil {
+			used += samples[i].Used
+		} else {
+			used += max(samples[i].Used-samples[i-1].Used, 0)
+		}
+	}
+	return used / span.Hours()
+}
+
+// DailyBurnRate estimates usage per hour from daily history, for providers
+// with no recent snapshots.
+func DailyBurnRate(history []models.DailyUsage) float64 {
+	return weightedDailyAverage(history) / 24
+}
+
+// EstimateExhaustion returns when remaining quota runs out at ratePerHour, or
+// nil when nothing is being consumed or it would last over a year. The result is flagged when it lands
+// before resetAt.
+func EstimateExhaustion(remaining, ratePerHour float64, now time.Time, resetAt *time.Time) *models.Exhaustion {
+	var at time.Time
+	switch {
+	case remaining <= 0:
+		at = now
+	case ratePerHour > 0 && remaining/ratePerHour < maxExhaustion.Hours():
+		at = now.Add(time.Duration(remaining / ratePerHour * float64(time.Hour)))
+	default:
+		return nil
+	}
+	return &models.Exhaustion{
+		At:          at,
+		BurnPerHour: ratePerHour,
+		BeforeReset: resetAt != nil && at.Before(*resetAt),
+	}
+}
@@ -0,0 +1,85 @@
+package predictor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurnRate(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	reset1, reset2 := now.Add(-7*time.Hour), now.Add(17*time.Hour)
+	samples := []Sample{
+		{At: now.Add(-30 * time.Hour), Used: 0, ResetAt: &reset1}, // before the window, only a baseline
+		{At: now.Add(-20 * time.Hour), Used: 40, ResetAt: &reset1},
+		{At: now.Add(-10 * time.Hour), Used: 60, ResetAt: &reset1},
+		{At: now.Add(-5 * time.Hour), Used: 10, ResetAt: &reset2}, // quota reset in between
+		{At: now, Used: 20, ResetAt: &reset2},
+	}
+	// From -30h: 40 + 20 + 10 + 10 = 80 over 30 hours.
+	if got := BurnRate(samples, now); got < 2.66 || got > 2.67 {
+		t.Errorf("Expected ~2.67/hour, got %.3f", got)
+	}
+	if got := BurnRate(samples[4:], now); got != 0 {
+		t.Errorf("Expected no rate from a single sample, got %.3f", got)
+	}
+}
+
+func TestBurnRateRollingDecline(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(3 * time.Hour)
+	samples := []Sample{
+		{At: now.Add(-4 * time.Hour), Used: 50, ResetAt: &reset},
+		// Old usage left the rolling window; the reset time did not move.
+		{At: now.Add(-2 * time.Hour), Used: 30, ResetAt: &reset},
+		{At: now, Used: 38, ResetAt: &reset},
+	}
+	if got := BurnRate(samples, now); got != 2 {
+		t.Errorf("Expected 8 used over 4 hours (2/hour), got %.3f", got)
+	}
+
+	// Without reset times (e.g. storage), a drop is never a reset.
+	for i := range samples {
+		samples[i].ResetAt = nil
+	}
+	if got := BurnRate(samples, now); got != 2 {
+		t.Errorf("Expected 2/hour without reset times, got %.3f", got)
+	}
+}
+
+func TestResetSince(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	at := func(h int) *time.Time { t := now.Add(time.Duration(h) * time.Hour); return &t }
+
+	prev := Sample{At: now, Used: 90, ResetAt: at(2)}
+	if r := (Sample{At: *at(3), Used: 5, ResetAt: at(7)}).ResetSince(prev); r == nil || !r.Equal(*at(2)) {
+		t.Errorf("Expected a reset at the previous reset instant, got %v", r)
+	}
+	// The binding window switched to a longer one before the old reset.
+	if r := (Sample{At: *at(1), Used: 40, ResetAt: at(96)}).ResetSince(prev); r != nil {
+		t.Errorf("Expected no reset before the previous reset instant, got %v", r)
+	}
+	// Reset times jitter by a few seconds between fetches.
+	jitter := now.Add(2*time.Hour + 2*time.Second)
+	if r := (Sample{At: *at(3), Used: 80, ResetAt: &jitter}).ResetSince(prev); r != nil {
+		t.Errorf("Expected jitter not to count as a reset, got %v", r)
+	}
+}
+
+func TestEstimateExhaustion(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(48 * time.Hour)
+
+	e := EstimateExhaustion(30, 1.5, now, &reset)
+	if e == nil || !e.At.Equal(now.Add(20*time.Hour)) || !e.BeforeReset {
+		t.Fatalf("Expected exhaustion in 20h before the reset, got %+v", e)
+	}
+	if e := EstimateExhaustion(300, 1.5, now, &reset); e == nil || e.BeforeReset {
+		t.Errorf("Expected exhaustion after the reset, got %+v", e)
+	}
+	if e := EstimateExhaustion(30, 0, now, &reset); e != nil {
+		t.Errorf("Expected no estimate without usage, got %+v", e)
+	}
+	if e := EstimateExhaustion(0, 0, now, nil); e == nil || !e.At.Equal(now) {
+		t.Errorf("Expected an exhausted quota to run out now, got %+v", e)
+	}
+}
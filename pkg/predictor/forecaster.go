@@ -0,0 +1,260 @@
+package predictor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// Forecaster predicts daily usage from past daily usage.
+type Forecaster interface {
+	// Name is the identifier used by --model and the forecast_model config key.
+	Name() string
+	// Forecast returns the expected usage on each of days, which all follow the
+	// last day of history. history is sorted oldest first.
+	Forecast(history []models.DailyUsage, days []time.Time) []float64
+}
+
+// DefaultModel is the forecaster used when none is configured.
+const DefaultModel = "weighted"
+
+var forecasters = []Forecaster{
+	WeightedAverage{},
+	EWMA{Alpha: 0.3},
+	LinearTrend{},
+	HoltWinters{Alpha: 0.3, Beta: 0.05, Gamma: 0.3, Period: 7},
+}
+
+// Models returns the names of the available forecasters.
+func Models() []string {
+	names := make([]string, len(forecasters))
+	for i, f := range forecasters {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// NewForecaster returns the forecaster with the given name.
+func NewForecaster(name string) (Forecaster, error) {
+	for _, f := range forecasters {
+		if f.Name() == name {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown forecast model %q; valid models: %s", name, strings.Join(Models(), ", "))
+}
+
+// WeightedAverage is the original qcli model: a weighted average of the last
+// 7 days, scaled down on days off (weekends and holidays of Calendar) by the
+// observed ratio between days off and working days.
+type WeightedAverage struct {
+	Calendar Calendar
+}
+
+func (WeightedAverage) Name() string { return "weighted" }
+
+func (m WeightedAverage) withCalendar(cal Calendar) Forecaster {
+	m.Calendar = cal
+	return m
+}
+
+func (m WeightedAverage) Forecast(history []models.DailyUsage, days []time.Time) []float64 {
+	avg := weightedDailyAverage(history)
+
+	// Simple heuristic: If days off are historically lower, we adjust the forecast for remaining days.
+	workdayAvg, offdayAvg := calculateDayTypeAverages(history, m.Calendar)
+	offdayRatio := 1.0
+	if workdayAvg > 0 {
+		offdayRatio = offdayAvg / workdayAvg
+	}
+	if offdayRatio < 0.1 {
+		offdayRatio = 0.1 // Minimum fallback
+	}
+
+	out := make([]float64, len(days))
+	for i, d := range days {
+		out[i] = avg
+		if !m.Calendar.IsWorkDay(d) {
+			out[i] *= offdayRatio
+		}
+	}
+	return out
+}
+
+// calendarAware is implemented by forecasters that treat working days and
+// days off differently.
+type calendarAware interface {
+	withCalendar(Calendar) Forecaster
+}
+
+// applyCalendar hands cal to forecasters that use it.
+func applyCalendar(f Forecaster, cal Calendar) Forecaster {
+	if ca, ok := f.(calendarAware); ok {
+		return ca.withCalendar(cal)
+	}
+	return f
+}
+
+// EWMA forecasts a flat level from an exponentially weighted moving average;
+// higher Alpha reacts faster to recent days.
+type EWMA struct {
+	Alpha float64
+}
+
+func (EWMA) Name() string { return "ewma" }
+
+func (m EWMA) Forecast(history []models.DailyUsage, days []time.Time) []float64 {
+	var level float64
+	for i, d := range history {
+		if i == 0 {
+			level = d.IncludedRequests
+			continue
+		}
+		level = m.Alpha*d.IncludedRequests + (1-m.Alpha)*level
+	}
+	out := make([]float64, len(days))
+	for i := range out {
+		out[i] = level
+	}
+	return out
+}
+
+// LinearTrend extrapolates a least-squares line through the history.
+type LinearTrend struct{}
+
+func (LinearTrend) Name() string { return "linear" }
+
+func (LinearTrend) Forecast(history []models.DailyUsage, days []time.Time) []float64 {
+	n := float64(len(history))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, d := range history {
+		x := float64(i)
+		sumX += x
+		sumY += d.IncludedRequests
+		sumXY += x * d.IncludedRequests
+		sumXX += x * x
+	}
+	var slope, intercept float64
+	if den := n*sumXX - sumX*sumX; den != 0 {
+		slope = (n*sumXY - sumX*sumY) / den
+	}
+	if n > 0 {
+		intercept = (sumY - slope*sumX) / n
+	}
+
+	out := make([]float64, len(days))
+	for i, d := range days {
+		x := float64(len(history)-1) + float64(daysAfter(history, d))
+		out[i] = math.Max(0, intercept+slope*x)
+	}
+	return out
+}
+
+// HoltWinters is additive triple exponential smoothing with a weekly season.
+// It needs two full seasons of history and falls back to LinearTrend otherwise.
+type HoltWinters struct {
+	Alpha, Beta, Gamma float64 // level, trend and season smoothing
+	Period             int
+}
+
+func (HoltWinters) Name() string { return "holt-winters" }
+
+func (m HoltWinters) Forecast(history []models.DailyUsage, days []time.Time) []float64 {
+	p := m.Period
+	if len(history) < 2*p {
+		return LinearTrend{}.Forecast(history, days)
+	}
+	x := make([]float64, len(history))
+	for i, d := range history {
+		x[i] = d.IncludedRequests
+	}
+
+	var first, second float64
+	for i := 0; i < p; i++ {
+		first += x[i]
+		second += x[p+i]
+	}
+	level := first / float64(p)
+	trend := (second - first) / float64(p*p)
+	season := make([]float64, p)
+	for i := 0; i < p; i++ {
+		season[i] = x[i] - level
+	}
+
+	for t := p; t < len(x); t++ {
+		s := season[t%p]
+		prevLevel := level
+		level = m.Alpha*(x[t]-s) + (1-m.Alpha)*(level+trend)
+		trend = m.Beta*(level-prevLevel) + (1-m.Beta)*trend
+		season[t%p] = m.Gamma*(x[t]-level) + (1-m.Gamma)*s
+	}
+
+	out := make([]float64, len(days))
+	last := len(x) - 1
+	for i, d := range days {
+		h := daysAfter(history, d)
+		out[i] = math.Max(0, level+float64(h)*trend+season[(last+h)%p])
+	}
+	return out
+}
+
+// BacktestResult is how accurately one model forecast one provider's history.
+type BacktestResult struct {
+	Model string `json:"model"`
+	// MAPE is the mean absolute percentage error of next-day forecasts.
+	MAPE float64 `json:"mape"`
+	// Points is the number of days scored; days with no usage are skipped.
+	Points int `json:"points"`
+}
+
+// backtestMinTrain is the number of days a model sees before it is scored.
+const backtestMinTrain = 7
+
+// Backtest replays normalized history: every day after the first week is
+// forecast from the days before it and compared with what actually happened.
+func Backtest(f Forecaster, history []models.DailyUsage, cal Calendar) BacktestResult {
+	history = Normalize(history, cal)
+	f = applyCalendar(f, cal)
+	res := BacktestResult{Model: f.Name()}
+	var sum float64
+	for t := backtestMinTrain; t < len(history); t++ {
+		actual := history[t].IncludedRequests
+		if actual <= 0 {
+			continue
+		}
+		day, err := time.ParseInLocation("2006-01-02", history[t].Date, cal.Loc())
+		if err != nil {
+			continue
+		}
+		predicted := f.Forecast(history[:t], []time.Time{day})[0]
+		sum += math.Abs(actual-predicted) / actual
+		res.Points++
+	}
+	if res.Points > 0 {
+		res.MAPE = sum / float64(res.Points) * 100
+	}
+	return res
+}
+
+// daysAfter returns how many days day is after the last entry of history (at least 1).
+func daysAfter(history []models.DailyUsage, day time.Time) int {
+	if len(history) == 0 {
+		return 1
+	}
+	last, err := time.Parse("2006-01-02", history[len(history)-1].Date)
+	if err != nil {
+		return 1
+	}
+	d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	if n := int(math.Round(d.Sub(last).Hours() / 24)); n > 1 {
+		return n
+	}
+	return 1
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
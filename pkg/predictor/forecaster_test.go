@@ -0,0 +1,87 @@
+package predictor
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// series builds daily history starting on Monday 2026-09-07 from fn(day index).
+func series(n int, fn func(i int) float64) []models.DailyUsage {
+	start := time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC)
+	out := make([]models.DailyUsage, n)
+	for i := range out {
+		out[i] = models.DailyUsage{Date: start.AddDate(0, 0, i).Format("2006-01-02"), IncludedRequests: fn(i)}
+	}
+	return out
+}
+
+func nextDay(history []models.DailyUsage, offset int) time.Time {
+	last, _ := time.Parse("2006-01-02", history[len(history)-1].Date)
+	return last.AddDate(0, 0, offset)
+}
+
+func TestForecastersOnConstantSeries(t *testing.T) {
+	history := series(21, func(int) float64 { return 40 })
+	for _, name := range Models() {
+		f, err := NewForecaster(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := f.Forecast(history, []time.Time{nextDay(history, 1), nextDay(history, 5)})
+		for _, v := range got {
+			if math.Abs(v-40) > 0.01 {
+				t.Errorf("%s: expected 40/day on a flat series, got %.2f", name, v)
+			}
+		}
+		if res := Backtest(f, history, Calendar{}); res.Points != 14 || res.MAPE > 0.01 {
+			t.Errorf("%s: expected a perfect backtest over 14 days, got %+v", name, res)
+		}
+	}
+}
+
+func TestLinearTrendFollowsRamp(t *testing.T) {
+	history := series(10, func(i int) float64 { return 10 + 5*float64(i) })
+	got := LinearTrend{}.Forecast(history, []time.Time{nextDay(history, 1), nextDay(history, 3)})
+	if math.Abs(got[0]-60) > 1e-9 || math.Abs(got[1]-70) > 1e-9 {
+		t.Errorf("Expected 60 and 70, got %.2f and %.2f", got[0], got[1])
+	}
+
+	ewma, _ := NewForecaster("ewma")
+	if lin, ew := Backtest(LinearTrend{}, history, Calendar{}), Backtest(ewma, history, Calendar{}); lin.MAPE >= ew.MAPE {
+		t.Errorf("Expected linear (%.1f%%) to beat ewma (%.1f%%) on a ramp", lin.MAPE, ew.MAPE)
+	}
+}
+
+func TestHoltWintersLearnsWeeklySeason(t *testing.T) {
+	// Busy weekdays, quiet weekends.
+	weekly := func(i int) float64 {
+		if i%7 >= 5 {
+			return 5
+		}
+		return 50
+	}
+	history := series(28, weekly)
+	hw, _ := NewForecaster("holt-winters")
+
+	saturday := nextDay(history, 6) // history ends on a Sunday
+	if saturday.Weekday() != time.Saturday {
+		t.Fatalf("Test setup: expected Saturday, got %s", saturday.Weekday())
+	}
+	got := hw.Forecast(history, []time.Time{nextDay(history, 1), saturday})
+	if math.Abs(got[0]-50) > 2 || math.Abs(got[1]-5) > 2 {
+		t.Errorf("Expected ~50 on Monday and ~5 on Saturday, got %.1f and %.1f", got[0], got[1])
+	}
+
+	if h, l := Backtest(hw, history, Calendar{}), Backtest(LinearTrend{}, history, Calendar{}); h.MAPE >= l.MAPE {
+		t.Errorf("Expected holt-winters (%.1f%%) to beat linear (%.1f%%) on a weekly pattern", h.MAPE, l.MAPE)
+	}
+}
+
+func TestNewForecasterUnknown(t *testing.T) {
+	if _, err := NewForecaster("prophet"); err == nil {
+		t.Error("Expected an error for an unknown model")
+	}
+}
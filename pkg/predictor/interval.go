@@ -0,0 +1,66 @@
+package predictor
+
+import (
+	"math"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// intervalLevels are the reported coverage levels with their two-sided normal z-scores.
+var intervalLevels = []struct {
+	level int
+	z     float64
+}{
+	{80, 1.2816},
+	{95, 1.9600},
+}
+
+// predictionIntervals bounds the forecast total. Daily usage is assumed to vary
+// independently around its mean with the variance seen in history, so over d
+// remaining days the total varies by σ²·d, plus σ²·d²/n for the error of a mean
+// estimated from n days. Bounds never drop below what was already used.
+func predictionIntervals(history []models.DailyUsage, days, used, predicted float64, cost func(float64) float64) []models.PredictionInterval {
+	n := float64(len(history))
+	var mean float64
+	for _, d := range history {
+		mean += d.IncludedRequests
+	}
+	mean /= n
+	var ss float64
+	for _, d := range history {
+		ss += (d.IncludedRequests - mean) * (d.IncludedRequests - mean)
+	}
+	sigma := math.Sqrt(ss/(n-1)) * math.Sqrt(days+days*days/n)
+
+	intervals := make([]models.PredictionInterval, 0, len(intervalLevels))
+	for _, l := range intervalLevels {
+		low := math.Max(used, predicted-l.z*sigma)
+		high := predicted + l.z*sigma
+		intervals = append(intervals, models.PredictionInterval{
+			Level:        l.level,
+			RequestsLow:  low,
+			RequestsHigh: high,
+			CostLow:      cost(low),
+			CostHigh:     cost(high),
+		})
+	}
+	return intervals
+}
+
+// confidenceLabel summarises how wide the 95% interval is compared to the usage
+// still to come: within ±12.5% is High, within ±30% Medium, otherwise Low.
+func confidenceLabel(intervals []models.PredictionInterval, used, predicted float64) string {
+	future := predicted - used
+	if future <= 0 {
+		return "High"
+	}
+	i95 := intervals[len(intervals)-1]
+	switch width := (i95.RequestsHigh - i95.RequestsLow) / future; {
+	case width <= 0.25:
+		return "High"
+	case width <= 0.6:
+		return "Medium"
+	default:
+		return "Low"
+	}
+}
@@ -1,119 +1,196 @@
 package predictor
 
 import (
+	"sort"
 	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
-// CalculatePrediction implements the weighted-average forecasting logic.
-func CalculatePrediction(history []models.DailyUsage, currentUsage *models.ProviderReport) *models.PredictionReport {
+// Options selects how CalculatePrediction forecasts and prices usage.
+type Options struct {
+	// Model forecasts daily usage; nil means the weighted average.
+	Model Forecaster
+	// Price, when known, turns usage beyond the entitlement into PredictedExtraCost.
+	Price *models.Price
+	// Calendar sets the time zone, working week and holidays.
+	Calendar Calendar
+}
+
+// CalculatePrediction forecasts usage at the end of the provider's quota window.
+func CalculatePrediction(history []models.DailyUsage, currentUsage *models.ProviderReport, opts Options) *models.PredictionReport {
 	if len(history) < 2 {
 		return &models.PredictionReport{Confidence: "Low (Insufficient Data)"}
 	}
+	model := opts.Model
+	if model == nil {
+		model = WeightedAverage{}
+	}
+	model = applyCalendar(model, opts.Calendar)
+	price := opts.Price
+	history = Normalize(history, opts.Calendar)
+
+	// 1. Forecast horizon: the provider's reset instant when known, otherwise
+	// the end of the calendar month in the user's time zone.
+	now := time.Now().In(opts.Calendar.Loc())
+	horizon := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+	resetAt := currentUsage.ResetAt
+	if resetAt != nil && resetAt.After(now) {
+		horizon = *resetAt
+	} else {
+		resetAt = nil
+	}
 
-	// 1. Calculate Weighted Daily Average (Last 7 days)
-	// Weights: [1.5, 1.5, 1.2, 1.2, 1.2, 1.0, 1.0] (newest to oldest)
-	weights := []float64{1.5, 1.5, 1.2, 1.2, 1.2, 1.0, 1.0}
-	var weightedSum float64
-	var weightSum float64
+	// 2. Forecast every remaining day; the rest of today and the reset day count fractionally.
+	days, fractions := remainingDays(now, horizon)
+	daily := model.Forecast(history, days)
+	var projectedFutureUsage, remaining float64
+	for i := range daily {
+		projectedFutureUsage += daily[i] * fractions[i]
+		remaining += fractions[i]
+	}
 
-	for i := 0; i < len(history) && i < len(weights); i++ {
-		weightedSum += history[i].IncludedRequests * weights[i]
-		weightSum += weights[i]
+	// Total = current usage from report + projected future
+	currentTotal := currentPeriodUsage(currentUsage)
+	predictedTotal := currentTotal + projectedFutureUsage
+
+	// 3. Cost Prediction: usage beyond the entitlement (all usage when the
+	// provider has none) at the provider's unit price. Usage beyond an
+	// entitlement is only billed when the provider permits overage.
+	costAt := func(total float64) float64 {
+		if price == nil || (currentUsage.Entitlement > 0 && !currentUsage.OveragePermitted) {
+			return 0
+		}
+		if billable := total - float64(currentUsage.Entitlement); billable > 0 {
+			return price.Cost(billable)
+		}
+		return 0
 	}
 
-	weightedAvg := weightedSum / weightSum
+	// 4. Uncertainty: 80% and 95% intervals from the variance of the history.
+	intervals := predictionIntervals(history, remaining, currentTotal, predictedTotal, costAt)
 
-	// 2. Weekend Compensation
-	// Simple heuristic: If weekends are historically lower, we adjust the forecast for remaining days.
-	weekdayAvg, weekendAvg := calculateDayTypeAverages(history)
-	weekendRatio := 1.0
-	if weekdayAvg > 0 {
-		weekendRatio = weekendAvg / weekdayAvg
+	pred := &models.PredictionReport{
+		PredictedMonthlyRequests: predictedTotal,
+		PredictedExtraCost:       costAt(predictedTotal),
+		Intervals:                intervals,
+		Confidence:               confidenceLabel(intervals, currentTotal, predictedTotal),
+		Price:                    price,
+		Model:                    model.Name(),
 	}
-	if weekendRatio < 0.1 {
-		weekendRatio = 0.1 // Minimum fallback
+	if resetAt != nil {
+		pred.ResetAt = resetAt
+		if currentUsage.WindowStart != nil {
+			pred.WindowDays = resetAt.Sub(*currentUsage.WindowStart).Hours() / 24
+		}
 	}
+	return pred
+}
 
-	// 3. Calculate Remaining Days in Month
-	now := time.Now().UTC()
-	currentDay := now.Day()
-	lastDay := time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
-	remainingDays := lastDay - currentDay
-
-	// Calculate remaining weekdays vs weekends
-	remainingWeekdays, remainingWeekends := countRemainingDays(now, remainingDays)
+// weightedDailyAverage averages the last 7 days of history, weighting recent
+// days more: [1.5, 1.5, 1.2, 1.2, 1.2, 1.0, 1.0] (newest to oldest).
+func weightedDailyAverage(history []models.DailyUsage) float64 {
+	weights := []float64{1.5, 1.5, 1.2, 1.2, 1.2, 1.0, 1.0}
+	var weightedSum float64
+	var weightSum float64
 
-	// 4. Forecast
-	projectedFutureUsage := (weightedAvg * float64(remainingWeekdays)) + (weightedAvg * weekendRatio * float64(remainingWeekends))
+	// Providers return history oldest first; weight from the newest day.
+	newest := make([]models.DailyUsage, len(history))
+	copy(newest, history)
+	sort.Slice(newest, func(i, j int) bool { return newest[i].Date > newest[j].Date })
 
-	// Total = current usage from report + projected future
-	// Current total usage for the month is usedRequests
-	currentTotal := float64(currentUsage.Entitlement - currentUsage.Remaining)
-	predictedTotal := currentTotal + projectedFutureUsage
+	for i := 0; i < len(newest) && i < len(weights); i++ {
+		weightedSum += newest[i].IncludedRequests * weights[i]
+		weightSum += weights[i]
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return weightedSum / weightSum
+}
 
-	// 5. Cost Prediction
-	extraCost := 0.0
-	if predictedTotal > float64(currentUsage.Entitlement) {
-		overage := predictedTotal - float64(currentUsage.Entitlement)
-		extraCost = overage * 0.04 // GitHub Copilot $0.04/request default
+// CalculateTrend compares the average of the most recent half of the history
+// against the older half and returns the change as a percentage.
+// It returns 0 when there is not enough data or the older half is empty.
+func CalculateTrend(history []models.DailyUsage) float64 {
+	if len(history) < 2 {
+		return 0
 	}
 
-	confidence := "High"
-	if len(history) < 4 {
-		confidence = "Medium"
+	sorted := make([]models.DailyUsage, len(history))
+	copy(sorted, history)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+	half := len(sorted) / 2
+	older, recent := sorted[:half], sorted[len(sorted)-half:]
+
+	var olderSum, recentSum float64
+	for _, d := range older {
+		olderSum += d.IncludedRequests
 	}
-	if len(history) < 3 {
-		confidence = "Low"
+	for _, d := range recent {
+		recentSum += d.IncludedRequests
 	}
+	if olderSum == 0 {
+		return 0
+	}
+	return (recentSum - olderSum) / olderSum * 100
+}
 
-	return &models.PredictionReport{
-		PredictedMonthlyRequests: predictedTotal,
-		PredictedExtraCost:       extraCost,
-		Confidence:               confidence,
+// currentPeriodUsage returns how much of the current period has already been consumed.
+func currentPeriodUsage(rep *models.ProviderReport) float64 {
+	if rep.Type == models.TypeTokensBased {
+		return float64(rep.TokensUsed)
 	}
+	return float64(rep.Entitlement - rep.Remaining)
 }
 
-func calculateDayTypeAverages(history []models.DailyUsage) (float64, float64) {
-	var weekdaySum, weekendSum float64
-	var weekdayCount, weekendCount float64
+// calculateDayTypeAverages returns the average usage on working days and on
+// days off. Missing days must have been filled in by Normalize.
+func calculateDayTypeAverages(history []models.DailyUsage, cal Calendar) (float64, float64) {
+	var workSum, offSum float64
+	var workCount, offCount float64
 
 	for _, d := range history {
-		t, err := time.Parse("2006-01-02", d.Date)
+		t, err := time.ParseInLocation("2006-01-02", d.Date, cal.Loc())
 		if err != nil {
 			continue
 		}
-		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
-			weekendSum += d.IncludedRequests
-			weekendCount++
+		if cal.IsWorkDay(t) {
+			workSum += d.IncludedRequests
+			workCount++
 		} else {
-			weekdaySum += d.IncludedRequests
-			weekdayCount++
+			offSum += d.IncludedRequests
+			offCount++
 		}
 	}
 
-	wdAvg := 0.0
-	if weekdayCount > 0 {
-		wdAvg = weekdaySum / weekdayCount
+	workAvg := 0.0
+	if workCount > 0 {
+		workAvg = workSum / workCount
 	}
-	weAvg := 0.0
-	if weekendCount > 0 {
-		weAvg = weekendSum / weekendCount
+	offAvg := 0.0
+	if offCount > 0 {
+		offAvg = offSum / offCount
 	}
 
-	return wdAvg, weAvg
+	return workAvg, offAvg
 }
 
-func countRemainingDays(start time.Time, days int) (int, int) {
-	weekdays, weekends := 0, 0
-	for i := 1; i <= days; i++ {
-		future := start.AddDate(0, 0, i)
-		if future.Weekday() == time.Saturday || future.Weekday() == time.Sunday {
-			weekends++
-		} else {
-			weekdays++
+// remainingDays returns the start of each day from start to end together with
+// the fraction of that day inside the range.
+func remainingDays(start, end time.Time) ([]time.Time, []float64) {
+	var days []time.Time
+	var fractions []float64
+	for cur := start; cur.Before(end); {
+		day := time.Date(cur.Year(), cur.Month(), cur.Day(), 0, 0, 0, 0, cur.Location())
+		next := day.AddDate(0, 0, 1)
+		if next.After(end) {
+			next = end
 		}
+		days = append(days, day)
+		fractions = append(fractions, next.Sub(cur).Hours()/24)
+		cur = next
 	}
-	return weekdays, weekends
+	return days, fractions
 }
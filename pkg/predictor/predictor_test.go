@@ -0,0 +1,49 @@
+package predictor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestCalculatePredictionIntervals(t *testing.T) {
+	reset := time.Now().Add(4 * 24 * time.Hour)
+	rep := &models.ProviderReport{Type: models.TypeQuotaBased, Entitlement: 300, Remaining: 100, ResetAt: &reset, OveragePermitted: true}
+	price := &models.Price{PerUnit: 0.04, Unit: models.UnitRequest}
+
+	noisy := series(14, func(i int) float64 { return []float64{20, 60}[i%2] })
+	pred := CalculatePrediction(noisy, rep, Options{Model: EWMA{Alpha: 0.3}, Price: price})
+	if len(pred.Intervals) != 2 {
+		t.Fatalf("Expected 80%% and 95%% intervals, got %+v", pred.Intervals)
+	}
+	i80, i95 := pred.Intervals[0], pred.Intervals[1]
+	if i80.Level != 80 || i95.Level != 95 {
+		t.Errorf("Unexpected levels %d and %d", i80.Level, i95.Level)
+	}
+	if !(i95.RequestsLow <= i80.RequestsLow && i80.RequestsLow < pred.PredictedMonthlyRequests &&
+		pred.PredictedMonthlyRequests < i80.RequestsHigh && i80.RequestsHigh <= i95.RequestsHigh) {
+		t.Errorf("Expected nested intervals around %.1f, got %+v", pred.PredictedMonthlyRequests, pred.Intervals)
+	}
+	if i95.RequestsLow < 200 {
+		t.Errorf("Expected the lower bound to stay above the 200 already used, got %.1f", i95.RequestsLow)
+	}
+	if i95.CostHigh <= pred.PredictedExtraCost {
+		t.Errorf("Expected the cost upper bound above $%.2f, got $%.2f", pred.PredictedExtraCost, i95.CostHigh)
+	}
+	if pred.Confidence != "Low" {
+		t.Errorf("Expected Low confidence for a noisy series, got %s", pred.Confidence)
+	}
+
+	capped := *rep
+	capped.OveragePermitted = false
+	if pred := CalculatePrediction(noisy, &capped, Options{Price: price}); pred.PredictedExtraCost != 0 || pred.Intervals[1].CostHigh != 0 {
+		t.Errorf("Expected no overage cost when overage is not permitted, got $%.2f", pred.PredictedExtraCost)
+	}
+
+	steady := series(14, func(int) float64 { return 20 })
+	pred = CalculatePrediction(steady, rep, Options{})
+	if iv := pred.Intervals[1]; iv.RequestsHigh-iv.RequestsLow > 1e-9 || pred.Confidence != "High" {
+		t.Errorf("Expected a zero-width interval and High confidence for a flat series, got %+v (%s)", iv, pred.Confidence)
+	}
+}
@@ -0,0 +1,96 @@
+// Package pricing holds the per-unit prices used to turn forecast usage into
+// cost. Built-in defaults are embedded and can be overridden from config.
+package pricing
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+//go:embed defaults.yaml
+var defaultsYAML []byte
+
+type entry struct {
+	Unit  string             `yaml:"unit"`
+	Price *float64           `yaml:"price"`
+	Plans map[string]float64 `yaml:"plans"`
+
+	source      string
+	planSources map[string]string
+}
+
+// defaultUnits are the units of providers that are not billed per request
+// but have no built-in price, so a configured price gets the right unit.
+var defaultUnits = map[string]string{
+	"vertex": models.UnitMillionTokens,
+}
+
+// Table maps provider IDs (and optionally plans or models) to prices.
+type Table struct {
+	entries map[string]*entry
+}
+
+// Defaults returns a table holding the embedded default prices.
+func Defaults() *Table {
+	var entries map[string]*entry
+	if err := yaml.Unmarshal(defaultsYAML, &entries); err != nil {
+		panic(fmt.Sprintf("pricing: invalid embedded defaults: %v", err))
+	}
+	for _, e := range entries {
+		e.source = "built-in"
+		e.planSources = make(map[string]string)
+		for plan := range e.Plans {
+			e.planSources[plan] = "built-in"
+		}
+	}
+	return &Table{entries: entries}
+}
+
+// Override sets the price of a provider, or of one of its plans when plan is
+// not empty. source describes where the price came from (e.g. a config key).
+func (t *Table) Override(providerID, plan string, price float64, source string) {
+	e, ok := t.entries[providerID]
+	if !ok {
+		e = &entry{Unit: defaultUnits[providerID], planSources: make(map[string]string)}
+		t.entries[providerID] = e
+	}
+	if plan == "" {
+		e.Price, e.source = &price, source
+		return
+	}
+	if e.Plans == nil {
+		e.Plans = make(map[string]float64)
+	}
+	plan = strings.ToLower(plan)
+	e.Plans[plan], e.planSources[plan] = price, source
+}
+
+// Lookup returns the price for a provider and plan, falling back to the
+// provider-wide price when the plan has none. ok is false when no price is known.
+func (t *Table) Lookup(providerID, plan string) (models.Price, bool) {
+	e, ok := t.entries[providerID]
+	if !ok {
+		return models.Price{}, false
+	}
+	if plan != "" {
+		if p, ok := e.Plans[strings.ToLower(plan)]; ok {
+			return models.Price{PerUnit: p, Unit: e.unit(), Plan: plan, Source: e.planSources[strings.ToLower(plan)]}, true
+		}
+	}
+	if e.Price == nil {
+		return models.Price{}, false
+	}
+	return models.Price{PerUnit: *e.Price, Unit: e.unit(), Source: e.source}, true
+}
+
+func (e *entry) unit() string {
+	if e.Unit == "" {
+		return models.UnitRequest
+	}
+	return e.Unit
+}
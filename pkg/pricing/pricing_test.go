@@ -0,0 +1,39 @@
+package pricing
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	table := Defaults()
+
+	p, ok := table.Lookup("copilot", "business")
+	if !ok || p.PerUnit != 0.04 || p.Source != "built-in" || p.Plan != "" {
+		t.Errorf("Expected the built-in provider price for an unlisted plan, got %+v", p)
+	}
+	if p, _ := table.Lookup("copilot", "Free"); p.PerUnit != 0 || p.Plan != "Free" {
+		t.Errorf("Expected the free plan price, got %+v", p)
+	}
+	if _, ok := table.Lookup("antigravity", ""); ok {
+		t.Error("Expected no price for a provider without overage billing")
+	}
+
+	table.Override("copilot", "", 0.05, "config: pricing.copilot.price")
+	table.Override("openrouter", "", 2, "config: pricing.openrouter.price")
+	if p, _ := table.Lookup("copilot", ""); p.PerUnit != 0.05 || p.Source != "config: pricing.copilot.price" {
+		t.Errorf("Expected the override to win, got %+v", p)
+	}
+	if p, ok := table.Lookup("openrouter", ""); !ok || p.Unit != "request" {
+		t.Errorf("Expected a new provider to default to per-request pricing, got %+v", p)
+	}
+}
+
+func TestPriceCost(t *testing.T) {
+	table := Defaults()
+	if _, ok := table.Lookup("vertex", ""); ok {
+		t.Error("Expected no built-in Vertex AI price: its token count mixes input and output tokens")
+	}
+	table.Override("vertex", "", 0.3, "config: pricing.vertex.price")
+	p, _ := table.Lookup("vertex", "")
+	if got := p.Cost(2_000_000); got != 0.6 {
+		t.Errorf("Expected 2M tokens at a configured $0.30 to cost $0.60, got $%.4f", got)
+	}
+}
@@ -5,23 +5,80 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider {
+		return &AntigravityProvider{
+			ClaudeBaseURL: s.BaseURLs["claude"],
+			GeminiBaseURL: s.BaseURLs["gemini"],
+			TokenURL:      s.TokenURLs["antigravity"],
+		}
+	})
+}
+
 // AntigravityProvider shows Claude and Gemini CLI as a unified "Antigravity" provider,
 // matching the opencodebar display with sub-rows and accurate reset times.
-type AntigravityProvider struct{}
+//
+// It only reads the anthropic OAuth entry and the antigravity refresh token;
+// claude.* and gemini.* belong to the standalone Claude and Gemini CLI
+// providers, so one credential never activates two providers.
+type AntigravityProvider struct {
+	// ClaudeBaseURL and GeminiBaseURL replace the APIs' default base URLs
+	// when set; they follow the claude and gemini providers' settings.
+	ClaudeBaseURL string
+	GeminiBaseURL string
+	// TokenURL replaces googleTokenURL, where the antigravity refresh token
+	// is exchanged for a Gemini access token, when set.
+	TokenURL string
+}
+
+const googleTokenURL = "https://oauth2.googleapis.com/token"
 
 func (a *AntigravityProvider) Name() string {
 	return "Antigravity"
 }
 
+func (a *AntigravityProvider) ID() string {
+	return "antigravity"
+}
+
 func (a *AntigravityProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
+func (a *AntigravityProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	var sources, masked, secrets []string
+	add := func(rawKey, secret string) {
+		sources = append(sources, sourceOrUnknown(cfg, rawKey))
+		masked = append(masked, MaskSecret(secret))
+		secrets = append(secrets, secret)
+	}
+	if t := cfg.GetNestedField("anthropic", "access"); t != "" {
+		add("anthropic", t)
+	} else if t := cfg.GetNestedField("anthropic", "key"); t != "" {
+		add("anthropic", t)
+	}
+	if hasAntigravityConfig(cfg) {
+		ant := cfg.RawKeys["antigravity"].(map[string]interface{})
+		add("antigravity", extractString(ant, "refresh_token"))
+	}
+	return DetectResult{
+		Available:   len(sources) > 0,
+		Source:      strings.Join(sources, ", "),
+		Credential:  strings.Join(masked, ", "),
+		Fingerprint: Fingerprint(secrets...),
+	}, nil
+}
+
+func (a *AntigravityProvider) Capabilities() Capabilities {
+	return Capabilities{MultiAccount: true}
+}
+
 func (a *AntigravityProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
 	var accounts []models.Account
 	var idx int
@@ -29,10 +86,10 @@ func (a *AntigravityProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAut
 	// --- Claude ---
 	claudeToken := cfg.GetNestedField("anthropic", "access")
 	if claudeToken == "" {
-		claudeToken = cfg.GetKey("Claude")
+		claudeToken = cfg.GetNestedField("anthropic", "key")
 	}
 	if claudeToken != "" {
-		acc, err := fetchClaudeAccount(ctx, claudeToken, idx)
+		acc, err := fetchClaudeAccount(ctx, a.ClaudeBaseURL, claudeToken, idx)
 		if err == nil {
 			accounts = append(accounts, acc)
 			idx++
@@ -40,18 +97,8 @@ func (a *AntigravityProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAut
 	}
 
 	// --- Gemini CLI ---
-	geminiToken := cfg.GetKey("Gemini CLI")
-	if geminiToken == "" {
-		// Try to refresh from antigravity config
-		var refreshErr error
-		geminiProvider := &GeminiProvider{}
-		geminiToken, refreshErr = geminiProvider.refreshToken(ctx, cfg)
-		if refreshErr != nil {
-			geminiToken = ""
-		}
-	}
-	if geminiToken != "" {
-		acc, err := fetchGeminiAccount(ctx, geminiToken, idx)
+	if geminiToken, err := refreshAntigravityToken(ctx, a.TokenURL, cfg); err == nil {
+		acc, err := fetchGeminiAccount(ctx, a.GeminiBaseURL, geminiToken, idx)
 		if err == nil {
 			accounts = append(accounts, acc)
 			idx++
@@ -65,19 +112,20 @@ func (a *AntigravityProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAut
 	return &models.ProviderReport{
 		Name:     a.Name(),
 		Type:     a.Type(),
+		Unit:     models.UnitPercent,
 		Accounts: accounts,
 	}, nil
 }
 
-func fetchClaudeAccount(ctx context.Context, token string, idx int) (models.Account, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.anthropic.com/api/oauth/usage", nil)
+func fetchClaudeAccount(ctx context.Context, baseURL, token string, idx int) (models.Account, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint(baseURL, claudeBaseURL, "/api/oauth/usage"), nil)
 	if err != nil {
 		return models.Account{}, err
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("anthropic-beta", "oauth-2025-04-20")
 
-	resp, err := (&http.Client{}).Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return models.Account{}, err
 	}
@@ -87,53 +135,37 @@ func fetchClaudeAccount(ctx context.Context, token string, idx int) (models.Acco
 		return models.Account{}, fmt.Errorf("claude API returned status %d", resp.StatusCode)
 	}
 
-	var result struct {
-		SevenDay struct {
-			Utilization float64 `json:"utilization"`
-			ResetsAt    string  `json:"resets_at"`
-		} `json:"seven_day"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	windows, err := parseClaudeUsage(resp.Body)
+	if err != nil {
 		return models.Account{}, err
 	}
 
-	usage := int(result.SevenDay.Utilization)
-	remaining := 100 - usage
-	if remaining < 0 {
-		remaining = 0
-	}
-
-	// Format a human-readable reset time
-	refreshLabel := "Claude"
-	if result.SevenDay.ResetsAt != "" {
-		if t, err := time.Parse(time.RFC3339, result.SevenDay.ResetsAt); err == nil {
-			hoursLeft := int(time.Until(t).Hours())
-			if hoursLeft < 24 {
-				refreshLabel = fmt.Sprintf("Claude: in %dh (%s)", hoursLeft, t.Format("15:04"))
-			} else {
-				refreshLabel = fmt.Sprintf("Claude: in %dd (%s)", hoursLeft/24, t.Format("01/02"))
-			}
-		}
-	}
-
-	return models.Account{
+	acc := models.Account{
 		Index:               idx,
-		Email:               refreshLabel,
-		Remaining:           remaining,
+		Email:               "Claude",
+		Remaining:           100,
 		Entitlement:         100,
-		RemainingPercentage: remaining,
-	}, nil
+		RemainingPercentage: 100,
+		Windows:             windows,
+	}
+	if w := bindingWindow(windows); w != nil {
+		remaining := max(100-int(w.UsedPercent), 0)
+		acc.Remaining, acc.RemainingPercentage = remaining, remaining
+		acc.ResetAt = w.ResetAt
+		acc.WindowKind = windowKind(time.Duration(w.WindowSeconds) * time.Second)
+	}
+	return acc, nil
 }
 
-func fetchGeminiAccount(ctx context.Context, token string, idx int) (models.Account, error) {
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://cloudcode-pa.googleapis.com/v1internal:retrieveUserQuota", nil)
+func fetchGeminiAccount(ctx context.Context, baseURL, token string, idx int) (models.Account, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint(baseURL, geminiBaseURL, "/v1internal:retrieveUserQuota"), nil)
 	if err != nil {
 		return models.Account{}, err
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := (&http.Client{}).Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return models.Account{}, err
 	}
@@ -143,55 +175,81 @@ func fetchGeminiAccount(ctx context.Context, token string, idx int) (models.Acco
 		return models.Account{}, fmt.Errorf("gemini API returned status %d", resp.StatusCode)
 	}
 
-	var result struct {
-		Buckets []struct {
-			DisplayName       string  `json:"displayName"`
-			RemainingFraction float64 `json:"remainingFraction"`
-			ResetTime         string  `json:"resetTime"`
-		} `json:"buckets"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	buckets, err := parseGeminiQuota(resp.Body)
+	if err != nil {
 		return models.Account{}, err
 	}
-	if len(result.Buckets) == 0 {
-		return models.Account{}, fmt.Errorf("no quota buckets found")
-	}
 
-	// Use the worst (lowest) remaining fraction
-	minFraction := 1.0
-	resetTimeStr := ""
-	for _, b := range result.Buckets {
-		if b.RemainingFraction < minFraction {
-			minFraction = b.RemainingFraction
-			resetTimeStr = b.ResetTime
-		}
-	}
-	remaining := int(minFraction * 100)
-	used := 100 - remaining
-
-	// Format reset label
-	refreshLabel := "Gemini"
-	if resetTimeStr != "" {
-		if t, err := time.Parse(time.RFC3339, resetTimeStr); err == nil {
-			hoursLeft := int(time.Until(t).Hours())
-			if hoursLeft < 24 {
-				refreshLabel = fmt.Sprintf("Gemini: in %dh (%s)", hoursLeft, t.Format("15:04"))
-			} else {
-				refreshLabel = fmt.Sprintf("Gemini: in %dd (%s)", hoursLeft/24, t.Format("01/02"))
-			}
-		}
+	acc := models.Account{
+		Index:  idx,
+		Email:  "Gemini",
+		Models: buckets,
 	}
-
-	return models.Account{
-		Index:               idx,
-		Email:               refreshLabel,
-		Remaining:           remaining,
-		Entitlement:         100,
-		RemainingPercentage: remaining,
-		ModelBreakdown:      map[string]int{"used": used},
-	}, nil
+	applyAccountModelHeadline(&acc)
+	return acc, nil
 }
 
 func (a *AntigravityProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
 	return nil, nil
 }
+
+// hasAntigravityConfig checks if an antigravity refresh token is available.
+func hasAntigravityConfig(cfg *models.OpenCodeAuthConfig) bool {
+	ant, ok := cfg.RawKeys["antigravity"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, hasRefresh := ant["refresh_token"]
+	return hasRefresh
+}
+
+// refreshAntigravityToken exchanges the antigravity refresh token for a
+// Gemini access token at tokenURL, or googleTokenURL when it is empty.
+func refreshAntigravityToken(ctx context.Context, tokenURL string, cfg *models.OpenCodeAuthConfig) (string, error) {
+	if tokenURL == "" {
+		tokenURL = googleTokenURL
+	}
+	ant, ok := cfg.RawKeys["antigravity"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("antigravity config not found")
+	}
+
+	clientID := extractString(ant, "client_id")
+	clientSecret := extractString(ant, "client_secret")
+	refreshToken := extractString(ant, "refresh_token")
+
+	if clientID == "" || refreshToken == "" {
+		return "", fmt.Errorf("missing client_id or refresh_token in antigravity config")
+	}
+
+	// In real implementation, clientSecret might be optional or hardcoded if it's a public client
+	// For Gemini CLI, it's often hardcoded in the app.
+
+	data := fmt.Sprintf("grant_type=refresh_token&client_id=%s&client_secret=%s&refresh_token=%s",
+		clientID, clientSecret, refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token refresh failed with status %d", resp.StatusCode)
+	}
+
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", err
+	}
+
+	return res.AccessToken, nil
+}
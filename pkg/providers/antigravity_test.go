@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestRefreshAntigravityTokenUsesTokenURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("refresh_token") != "1//refresh" {
+			t.Errorf("form = %v, %v", r.PostForm, err)
+		}
+		w.Write([]byte(`{"access_token": "ya29.access"}`))
+	}))
+	defer srv.Close()
+
+	cfg := &models.OpenCodeAuthConfig{RawKeys: map[string]interface{}{
+		"antigravity": map[string]interface{}{"client_id": "id", "refresh_token": "1//refresh"},
+	}}
+	got, err := refreshAntigravityToken(context.Background(), srv.URL, cfg)
+	if err != nil || got != "ya29.access" {
+		t.Errorf("refreshAntigravityToken() = %q, %v, want ya29.access", got, err)
+	}
+}
@@ -4,29 +4,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider { return &ClaudeProvider{BaseURL: s.BaseURLs["claude"]} })
+}
+
 // ClaudeProvider implements the Claude Quota-based fetcher
-type ClaudeProvider struct{}
+type ClaudeProvider struct {
+	// BaseURL replaces claudeBaseURL when set.
+	BaseURL string
+}
+
+const claudeBaseURL = "https://api.anthropic.com"
 
 func (c *ClaudeProvider) Name() string {
 	return "Claude"
 }
 
+func (c *ClaudeProvider) ID() string {
+	return "claude"
+}
+
 func (c *ClaudeProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
+func (c *ClaudeProvider) Aliases() []string {
+	return []string{"anthropic"}
+}
+
+// KeyNames lists where the Claude key is stored in auth.json.
+func (c *ClaudeProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "claude.key", Nested: []string{"claude"}}
+}
+
+func (c *ClaudeProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	return detectKey(cfg, c.KeyNames()), nil
+}
+
+func (c *ClaudeProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
 func (c *ClaudeProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
-	apiKey := cfg.GetKey(c.Name())
+	apiKey := cfg.GetKey(c.KeyNames())
 	if apiKey == "" {
 		return nil, fmt.Errorf("no API key provided")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.anthropic.com/api/oauth/usage", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint(c.BaseURL, claudeBaseURL, "/api/oauth/usage"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +66,6 @@ func (c *ClaudeProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("anthropic-beta", "oauth-2025-04-20")
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -45,31 +76,70 @@ func (c *ClaudeProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 		return nil, fmt.Errorf("claude API returned status %d", resp.StatusCode)
 	}
 
-	var result struct {
-		SevenDay struct {
-			Utilization float64 `json:"utilization"`
-			ResetsAt    string  `json:"resets_at"`
-		} `json:"seven_day"`
+	windows, err := parseClaudeUsage(resp.Body)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	rep := &models.ProviderReport{
+		Name:        c.Name(),
+		Type:        c.Type(),
+		Entitlement: 100,
+		Remaining:   100,
+		Windows:     windows,
+	}
+	if w := bindingWindow(windows); w != nil {
+		applyWindow(rep, *w)
 	}
+	return rep, nil
+}
 
-	usage := int(result.SevenDay.Utilization)
-	remaining := 100 - usage
-	if remaining < 0 {
-		remaining = 0
+// claudeWindows are the rate-limit windows reported by /api/oauth/usage, in
+// display order. Windows the plan does not have come back as null.
+var claudeWindows = []struct {
+	key, name string
+	length    time.Duration
+}{
+	{"five_hour", "5-hour", 5 * time.Hour},
+	{"seven_day", "7-day", 7 * 24 * time.Hour},
+	{"seven_day_opus", "7-day Opus", 7 * 24 * time.Hour},
+	{"seven_day_sonnet", "7-day Sonnet", 7 * 24 * time.Hour},
+}
+
+// parseClaudeUsage decodes an /api/oauth/usage response into quota windows,
+// marking the binding one.
+func parseClaudeUsage(r io.Reader) ([]models.QuotaWindow, error) {
+	var result map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, err
 	}
 
-	return &models.ProviderReport{
-		Name:            c.Name(),
-		Type:            c.Type(),
-		Remaining:       remaining,
-		Entitlement:     100,
-		UsagePercentage: usage,
-		RefreshTime:     result.SevenDay.ResetsAt,
-	}, nil
+	var windows []models.QuotaWindow
+	for _, cw := range claudeWindows {
+		var u *struct {
+			Utilization float64 `json:"utilization"`
+			ResetsAt    string  `json:"resets_at"`
+		}
+		if raw, ok := result[cw.key]; ok {
+			if err := json.Unmarshal(raw, &u); err != nil {
+				return nil, fmt.Errorf("claude %s window: %w", cw.key, err)
+			}
+		}
+		if u == nil {
+			continue
+		}
+		w := models.QuotaWindow{
+			Name:          cw.name,
+			UsedPercent:   u.Utilization,
+			WindowSeconds: int64(cw.length / time.Second),
+		}
+		if t, err := time.Parse(time.RFC3339, u.ResetsAt); err == nil {
+			w.ResetAt = &t
+		}
+		windows = append(windows, w)
+	}
+	markBinding(windows)
+	return windows, nil
 }
 
 func (c *ClaudeProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
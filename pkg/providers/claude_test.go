@@ -0,0 +1,93 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestParseClaudeUsage(t *testing.T) {
+	body := `{
+		"five_hour": {"utilization": 92, "resets_at": "2026-10-16T15:00:00Z"},
+		"seven_day": {"utilization": 40, "resets_at": "2026-10-20T09:00:00Z"},
+		"seven_day_opus": null,
+		"extra_usage": {"is_enabled": false}
+	}`
+	windows, err := parseClaudeUsage(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(windows) != 2 {
+		t.Fatalf("got %d windows, want 2: %+v", len(windows), windows)
+	}
+	five, seven := windows[0], windows[1]
+	if five.Name != "5-hour" || five.UsedPercent != 92 || five.WindowSeconds != 5*3600 || five.ResetAt == nil {
+		t.Errorf("unexpected 5-hour window %+v", five)
+	}
+	if !five.Binding || seven.Binding {
+		t.Errorf("the 5-hour window should be binding: %+v", windows)
+	}
+}
+
+func TestClaudeHeadlineFollowsBindingWindow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"five_hour": {"utilization": 92, "resets_at": "2026-10-16T15:00:00Z"},
+			"seven_day": {"utilization": 40, "resets_at": "2026-10-20T09:00:00Z"}
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &ClaudeProvider{BaseURL: srv.URL}
+	rep, err := p.Fetch(context.Background(), &models.OpenCodeAuthConfig{
+		RawKeys: map[string]interface{}{"claude.key": "sk-ant-test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	if rep.UsagePercentage != 92 || rep.Remaining != 8 || rep.WindowKind != "5-hour" ||
+		rep.ResetAt == nil || !rep.ResetAt.Equal(want) {
+		t.Errorf("headline should describe the binding 5-hour window, got %d%% used, %d left, %s, reset %v",
+			rep.UsagePercentage, rep.Remaining, rep.WindowKind, rep.ResetAt)
+	}
+	if rep.Unit != models.UnitPercent {
+		t.Errorf("Unit = %q, want %q for a percentage quota", rep.Unit, models.UnitPercent)
+	}
+}
+
+func TestMarkBindingPrefersLaterReset(t *testing.T) {
+	windows, _ := parseClaudeUsage(strings.NewReader(`{
+		"five_hour": {"utilization": 100, "resets_at": "2026-10-16T15:00:00Z"},
+		"seven_day": {"utilization": 100, "resets_at": "2026-10-20T09:00:00Z"}
+	}`))
+	if windows[0].Binding || !windows[1].Binding {
+		t.Errorf("with both windows exhausted the 7-day one binds longer: %+v", windows)
+	}
+}
+
+func TestWindowName(t *testing.T) {
+	for secs, want := range map[int64]string{18000: "5-hour", 604800: "7-day", 3600: "1-hour"} {
+		if got := windowName(time.Duration(secs) * time.Second); got != want {
+			t.Errorf("windowName(%ds) = %q, want %q", secs, got, want)
+		}
+	}
+}
+
+func TestWindowKind(t *testing.T) {
+	for length, want := range map[time.Duration]string{
+		5 * time.Hour:       "5-hour",
+		24 * time.Hour:      models.WindowDaily,
+		7 * 24 * time.Hour:  models.WindowWeekly,
+		30 * 24 * time.Hour: models.WindowMonthly,
+	} {
+		if got := windowKind(length); got != want {
+			t.Errorf("windowKind(%s) = %q, want %q", length, got, want)
+		}
+	}
+}
@@ -11,35 +11,64 @@ import (
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider { return &CopilotProvider{BaseURL: s.BaseURLs["copilot"]} })
+}
+
 // CopilotProvider implements the GitHub Copilot Quota-based fetcher using the OAuth token
 // stored in auth.json — no browser cookies required.
-type CopilotProvider struct{}
+type CopilotProvider struct {
+	// BaseURL replaces copilotBaseURL (for GitHub Enterprise Server, https://HOST/api/v3) when set.
+	BaseURL string
+}
+
+const copilotBaseURL = "https://api.github.com"
 
 func (c *CopilotProvider) Name() string {
 	return "GitHub Copilot"
 }
 
+func (c *CopilotProvider) ID() string {
+	return "copilot"
+}
+
 func (c *CopilotProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
+func (c *CopilotProvider) Aliases() []string {
+	return []string{"github-copilot"}
+}
+
+// KeyNames lists where the Copilot token is stored in auth.json.
+func (c *CopilotProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "copilot.token", Nested: []string{"github-copilot"}}
+}
+
+func (c *CopilotProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	return detectKey(cfg, c.KeyNames()), nil
+}
+
+func (c *CopilotProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
 func (c *CopilotProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
 	// Try the nested "github-copilot" key from auth.json
 	token := cfg.GetNestedField("github-copilot", "access")
 	if token == "" {
-		token = cfg.GetKey(c.Name())
+		token = cfg.GetKey(c.KeyNames())
 	}
 	if token == "" {
 		return nil, fmt.Errorf("no GitHub Copilot OAuth token found in auth.json")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.github.com/copilot_internal/user", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint(c.BaseURL, copilotBaseURL, "/copilot_internal/user"), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "token "+token)
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -52,6 +81,7 @@ func (c *CopilotProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthCon
 	}
 
 	var result struct {
+		CopilotPlan       string `json:"copilot_plan"`
 		QuotaResetDateUTC string `json:"quota_reset_date_utc"`
 		QuotaSnapshots    struct {
 			PremiumInteractions struct {
@@ -78,28 +108,21 @@ func (c *CopilotProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthCon
 		usagePct = (used * 100) / premium.Entitlement
 	}
 
-	// Format reset time
-	refreshStr := "Monthly"
-	if result.QuotaResetDateUTC != "" {
-		if resetTime, err := time.Parse(time.RFC3339, result.QuotaResetDateUTC); err == nil {
-			daysLeft := int(time.Until(resetTime).Hours() / 24)
-			if daysLeft > 0 {
-				refreshStr = fmt.Sprintf("Monthly: in %dd (%s)", daysLeft, resetTime.Format("01/02"))
-			} else {
-				refreshStr = fmt.Sprintf("Monthly: %s", resetTime.Format("01/02"))
-			}
-		}
-	}
-
-	return &models.ProviderReport{
+	rep := &models.ProviderReport{
 		Name:             c.Name(),
 		Type:             c.Type(),
 		Remaining:        premium.Remaining,
 		Entitlement:      premium.Entitlement,
 		UsagePercentage:  usagePct,
 		OveragePermitted: premium.OveragePermitted,
-		RefreshTime:      refreshStr,
-	}, nil
+		WindowKind:       models.WindowMonthly,
+		Plan:             result.CopilotPlan,
+	}
+	if resetTime, err := time.Parse(time.RFC3339, result.QuotaResetDateUTC); err == nil {
+		start := resetTime.AddDate(0, -1, 0)
+		rep.ResetAt, rep.WindowStart = &resetTime, &start
+	}
+	return rep, nil
 }
 
 func (c *CopilotProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
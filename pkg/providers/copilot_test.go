@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestCopilotFetchUsesBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/copilot_internal/user" {
+			t.Errorf("requested %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "token gho_test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Write([]byte(`{
+			"copilot_plan": "business",
+			"quota_reset_date_utc": "2026-03-01T00:00:00Z",
+			"quota_snapshots": {"premium_interactions": {"entitlement": 300, "remaining": 120}}
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &CopilotProvider{BaseURL: srv.URL + "/api/v3/"}
+	rep, err := p.Fetch(context.Background(), &models.OpenCodeAuthConfig{
+		RawKeys: map[string]interface{}{
+			"github-copilot": map[string]interface{}{"access": "gho_test"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Remaining != 120 || rep.Entitlement != 300 || rep.UsagePercentage != 60 || rep.Plan != "business" || rep.Unit != "" {
+		t.Errorf("unexpected report %+v", rep)
+	}
+	if rep.ResetAt == nil || rep.ResetAt.Month() != 3 {
+		t.Errorf("ResetAt = %v, want March 1", rep.ResetAt)
+	}
+}
+
+func TestCopilotDetectFlatKey(t *testing.T) {
+	cfg := &models.OpenCodeAuthConfig{
+		RawKeys: map[string]interface{}{"copilot.token": "gho_flat_token_1234"},
+		Sources: map[string]string{"copilot.token": "auth.json"},
+	}
+	res, err := (&CopilotProvider{}).Detect(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Available || res.Source != "auth.json" || res.Fingerprint != Fingerprint("gho_flat_token_1234") {
+		t.Errorf("flat copilot.token should be detected, got %+v", res)
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	if got := endpoint("", copilotBaseURL, "/copilot_internal/user"); got != "https://api.github.com/copilot_internal/user" {
+		t.Errorf("default endpoint = %s", got)
+	}
+	if got := endpoint("https://ghe.example.com/api/v3/", copilotBaseURL, "/copilot_internal/user"); got != "https://ghe.example.com/api/v3/copilot_internal/user" {
+		t.Errorf("configured endpoint = %s", got)
+	}
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// DefaultTimeout bounds a single provider's Fetch when the caller does not set one.
+const DefaultTimeout = 15 * time.Second
+
+// FetchOptions controls how FetchAll queries providers.
+type FetchOptions struct {
+	// Timeout bounds each provider's Fetch; DefaultTimeout is used when zero.
+	Timeout time.Duration
+	// ProviderTimeouts overrides Timeout for individual providers, keyed by ID().
+	ProviderTimeouts map[string]time.Duration
+}
+
+func (o FetchOptions) timeoutFor(p Provider) time.Duration {
+	if d := o.ProviderTimeouts[p.ID()]; d > 0 {
+		return d
+	}
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
+// FetchAll runs Fetch on every provider concurrently, each under its own timeout.
+// A failing provider never aborts the run: its error is turned into a report row
+// with ErrorMsg set. Reports are returned in the same order as the providers.
+func FetchAll(ctx context.Context, active []Provider, cfg *models.OpenCodeAuthConfig, opts FetchOptions) []*models.ProviderReport {
+	return fetchAll(ctx, active, cfg, opts, false)
+}
+
+// FetchAllWithHistory behaves like FetchAll but also calls FetchHistory for every
+// provider with the History capability that fetched successfully and did not
+// already attach its history.
+// History failures are not fatal; the report simply has no History.
+func FetchAllWithHistory(ctx context.Context, active []Provider, cfg *models.OpenCodeAuthConfig, opts FetchOptions) []*models.ProviderReport {
+	return fetchAll(ctx, active, cfg, opts, true)
+}
+
+func fetchAll(ctx context.Context, active []Provider, cfg *models.OpenCodeAuthConfig, opts FetchOptions, withHistory bool) []*models.ProviderReport {
+	reports := make([]*models.ProviderReport, len(active))
+	var wg sync.WaitGroup
+	for i, p := range active {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			timeout := opts.timeoutFor(p)
+			pctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			rep, err := p.Fetch(pctx, cfg)
+			reports[i] = toReport(p, rep, err, timeout)
+
+			if withHistory && p.Capabilities().History && reports[i].ErrorMsg == "" && len(reports[i].History) == 0 {
+				if history, err := p.FetchHistory(pctx, cfg); err == nil {
+					reports[i].History = history
+				}
+			}
+		}(i, p)
+	}
+	wg.Wait()
+
+	return reports
+}
+
+// toReport normalizes the result of a Fetch call into a non-nil report.
+func toReport(p Provider, rep *models.ProviderReport, err error, timeout time.Duration) *models.ProviderReport {
+	if err == nil && rep != nil {
+		rep.ID = p.ID()
+		return rep
+	}
+
+	// Some providers return a partial report alongside the error; keep only its identity.
+	errRep := &models.ProviderReport{ID: p.ID(), Name: p.Name(), Type: p.Type()}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		errRep.ErrorMsg = fmt.Sprintf("timed out after %s", timeout)
+	case err != nil:
+		errRep.ErrorMsg = err.Error()
+	default:
+		errRep.ErrorMsg = "no data returned"
+	}
+	return errRep
+}
@@ -4,37 +4,65 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider { return &GeminiProvider{BaseURL: s.BaseURLs["gemini"]} })
+}
+
 // GeminiProvider implements the Gemini CLI API fetcher
-type GeminiProvider struct{}
+type GeminiProvider struct {
+	// BaseURL replaces geminiBaseURL when set.
+	BaseURL string
+}
+
+const geminiBaseURL = "https://cloudcode-pa.googleapis.com"
 
 func (g *GeminiProvider) Name() string {
 	return "Gemini CLI"
 }
 
+func (g *GeminiProvider) ID() string {
+	return "gemini"
+}
+
 func (g *GeminiProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
-func (g *GeminiProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
-	// 1. Try to get access token from auth.json
-	accessToken := cfg.GetKey(g.Name())
+func (g *GeminiProvider) Aliases() []string {
+	return []string{"gemini-cli"}
+}
 
-	// 2. If missing, try to refresh using antigravity config
+// KeyNames lists where the Gemini CLI access token is stored in auth.json.
+func (g *GeminiProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "gemini.key", Nested: []string{"gemini"}}
+}
+
+func (g *GeminiProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	return detectKey(cfg, g.KeyNames()), nil
+}
+
+func (g *GeminiProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+func (g *GeminiProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	// The antigravity refresh token belongs to the Antigravity provider, so
+	// only the Gemini CLI's own token is used here.
+	accessToken := cfg.GetKey(g.KeyNames())
 	if accessToken == "" {
-		var err error
-		accessToken, err = g.refreshToken(ctx, cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get/refresh Gemini token: %w", err)
-		}
+		return nil, fmt.Errorf("no Gemini CLI token found in auth.json")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://cloudcode-pa.googleapis.com/v1internal:retrieveUserQuota", nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint(g.BaseURL, geminiBaseURL, "/v1internal:retrieveUserQuota"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -42,115 +70,142 @@ func (g *GeminiProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		// Try refresh once
-		accessToken, err = g.refreshToken(ctx, cfg)
-		if err == nil {
-			// Retry request
-			req.Header.Set("Authorization", "Bearer "+accessToken)
-			resp, err = client.Do(req)
-			if err != nil {
-				return nil, err
-			}
-			defer resp.Body.Close()
-		}
-	}
-
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("gemini API returned status %d", resp.StatusCode)
 	}
 
+	buckets, err := parseGeminiQuota(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &models.ProviderReport{
+		Name:   g.Name(),
+		Type:   g.Type(),
+		Models: buckets,
+	}
+	applyModelHeadline(rep)
+	return rep, nil
+}
+
+// parseGeminiQuota decodes a retrieveUserQuota response into one entry per
+// model bucket, sorted by model and token type.
+func parseGeminiQuota(r io.Reader) ([]models.ModelQuota, error) {
 	var result struct {
 		Buckets []struct {
+			ModelID           string  `json:"modelId"`
 			DisplayName       string  `json:"displayName"`
+			TokenType         string  `json:"tokenType"`
 			RemainingFraction float64 `json:"remainingFraction"`
+			ResetTime         string  `json:"resetTime"`
 		} `json:"buckets"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
 		return nil, err
 	}
-
 	if len(result.Buckets) == 0 {
 		return nil, fmt.Errorf("no quota buckets found")
 	}
 
-	// Use the lowest remaining fraction as the primary metric
-	minFraction := 1.0
+	buckets := make([]models.ModelQuota, 0, len(result.Buckets))
 	for _, b := range result.Buckets {
-		if b.RemainingFraction < minFraction {
-			minFraction = b.RemainingFraction
+		q := models.ModelQuota{
+			Model:             b.ModelID,
+			TokenType:         b.TokenType,
+			RemainingFraction: b.RemainingFraction,
+		}
+		if q.Model == "" {
+			q.Model = b.DisplayName
+		}
+		if t, err := time.Parse(time.RFC3339, b.ResetTime); err == nil {
+			q.ResetAt = &t
 		}
+		buckets = append(buckets, q)
 	}
-
-	usage := int((1.0 - minFraction) * 100)
-	remaining := int(minFraction * 100)
-
-	return &models.ProviderReport{
-		Name:            g.Name(),
-		Type:            g.Type(),
-		Remaining:       remaining,
-		Entitlement:     100,
-		UsagePercentage: usage,
-	}, nil
+	sort.SliceStable(buckets, func(i, j int) bool {
+		if buckets[i].Model != buckets[j].Model {
+			return buckets[i].Model < buckets[j].Model
+		}
+		return buckets[i].TokenType < buckets[j].TokenType
+	})
+	return buckets, nil
 }
 
-func (g *GeminiProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
-	return nil, nil
+// applyModelHeadline sets the report's headline quota to its tightest model
+// bucket: the lowest remaining fraction is the primary metric.
+func applyModelHeadline(rep *models.ProviderReport) {
+	low := lowestBucket(rep.Models)
+	rep.Entitlement, rep.Unit = 100, models.UnitPercent
+	rep.Remaining = int(low.RemainingFraction * 100)
+	rep.UsagePercentage = 100 - rep.Remaining
+	rep.ResetAt = low.ResetAt
 }
 
-func (g *GeminiProvider) refreshToken(ctx context.Context, cfg *models.OpenCodeAuthConfig) (string, error) {
-	ant, ok := cfg.RawKeys["antigravity"].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("antigravity config not found")
-	}
-
-	clientID := extractString(ant, "client_id")
-	clientSecret := extractString(ant, "client_secret")
-	refreshToken := extractString(ant, "refresh_token")
-
-	if clientID == "" || refreshToken == "" {
-		return "", fmt.Errorf("missing client_id or refresh_token in antigravity config")
-	}
-
-	// In real implementation, clientSecret might be optional or hardcoded if it's a public client
-	// For Gemini CLI, it's often hardcoded in the app.
-
-	data := fmt.Sprintf("grant_type=refresh_token&client_id=%s&client_secret=%s&refresh_token=%s",
-		clientID, clientSecret, refreshToken)
-
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://oauth2.googleapis.com/token", strings.NewReader(data))
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+// applyAccountModelHeadline is applyModelHeadline for an account sub-row.
+func applyAccountModelHeadline(acc *models.Account) {
+	low := lowestBucket(acc.Models)
+	remaining := int(low.RemainingFraction * 100)
+	acc.Entitlement = 100
+	acc.Remaining, acc.RemainingPercentage = remaining, remaining
+	acc.ModelBreakdown = map[string]int{"used": 100 - remaining}
+	acc.ResetAt = low.ResetAt
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token refresh failed with status %d", resp.StatusCode)
+// NarrowModels keeps only the model quota buckets of rep and its accounts
+// whose name contains pattern, ignoring case, and recomputes their headline
+// quota from the buckets left so it describes the selected models. An
+// exhaustion estimate made for the full headline is dropped when the tightest
+// bucket changes. It reports whether any bucket matched.
+func NarrowModels(rep *models.ProviderReport, pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	keep := func(buckets []models.ModelQuota) ([]models.ModelQuota, bool) {
+		var kept []models.ModelQuota
+		for _, b := range buckets {
+			if strings.Contains(strings.ToLower(b.Model), pattern) {
+				kept = append(kept, b)
+			}
+		}
+		// The headline only changes when the tightest bucket was filtered out.
+		changed := len(buckets) > 0 && (len(kept) == 0 || lowestBucket(kept) != lowestBucket(buckets))
+		return kept, changed
+	}
+
+	var changed, matched bool
+	if rep.Models, changed = keep(rep.Models); changed && len(rep.Models) > 0 {
+		applyModelHeadline(rep)
+		rep.Exhaustion = nil
+	}
+	matched = len(rep.Models) > 0
+	for i := range rep.Accounts {
+		acc := &rep.Accounts[i]
+		if acc.Models, changed = keep(acc.Models); changed && len(acc.Models) > 0 {
+			applyAccountModelHeadline(acc)
+			acc.Exhaustion = nil
+		}
+		matched = matched || len(acc.Models) > 0
 	}
+	return matched
+}
 
-	var res struct {
-		AccessToken string `json:"access_token"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return "", err
+// lowestBucket returns the bucket with the least quota left.
+func lowestBucket(buckets []models.ModelQuota) models.ModelQuota {
+	low := buckets[0]
+	for _, b := range buckets[1:] {
+		if b.RemainingFraction < low.RemainingFraction {
+			low = b
+		}
 	}
+	return low
+}
 
-	return res.AccessToken, nil
+func (g *GeminiProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
+	return nil, nil
 }
 
 func extractString(m map[string]interface{}, key string) string {
@@ -0,0 +1,74 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestParseGeminiQuota(t *testing.T) {
+	body := `{"buckets": [
+		{"modelId": "gemini-2.5-pro", "tokenType": "REQUESTS", "remainingFraction": 0.1, "resetTime": "2026-10-17T07:00:00Z"},
+		{"modelId": "gemini-2.5-flash", "tokenType": "REQUESTS", "remainingFraction": 0.8, "resetTime": "2026-10-17T07:00:00Z"},
+		{"displayName": "Legacy bucket", "remainingFraction": 1}
+	]}`
+	buckets, err := parseGeminiQuota(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, b := range buckets {
+		names = append(names, b.Model)
+	}
+	if got := strings.Join(names, ","); got != "Legacy bucket,gemini-2.5-flash,gemini-2.5-pro" {
+		t.Errorf("buckets = %s, want sorted by model with displayName as fallback", got)
+	}
+	if buckets[1].TokenType != "REQUESTS" || buckets[1].ResetAt == nil {
+		t.Errorf("unexpected flash bucket %+v", buckets[1])
+	}
+	if low := lowestBucket(buckets); low.Model != "gemini-2.5-pro" {
+		t.Errorf("lowest bucket = %s, want gemini-2.5-pro", low.Model)
+	}
+
+	if _, err := parseGeminiQuota(strings.NewReader(`{"buckets": []}`)); err == nil {
+		t.Error("expected an error for a response without buckets")
+	}
+}
+
+func TestNarrowModelsRecomputesHeadline(t *testing.T) {
+	proReset := time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC)
+	flashReset := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	buckets := []models.ModelQuota{
+		{Model: "gemini-2.5-flash", RemainingFraction: 0.8, ResetAt: &flashReset},
+		{Model: "gemini-2.5-pro", RemainingFraction: 0.1, ResetAt: &proReset},
+	}
+	rep := &models.ProviderReport{
+		Models:     append([]models.ModelQuota(nil), buckets...),
+		Exhaustion: &models.Exhaustion{At: proReset},
+		Accounts:   []models.Account{{Email: "Gemini", Models: append([]models.ModelQuota(nil), buckets...)}},
+	}
+	applyModelHeadline(rep)
+	applyAccountModelHeadline(&rep.Accounts[0])
+	if rep.Remaining != 10 || rep.Accounts[0].Remaining != 10 {
+		t.Fatalf("headline should start at the pro bucket, got %d and %d", rep.Remaining, rep.Accounts[0].Remaining)
+	}
+
+	if !NarrowModels(rep, "FLASH") {
+		t.Fatal("expected flash to match")
+	}
+	if len(rep.Models) != 1 || rep.Remaining != 80 || rep.UsagePercentage != 20 || !rep.ResetAt.Equal(flashReset) {
+		t.Errorf("headline should follow the flash bucket, got %d%% left, reset %v", rep.Remaining, rep.ResetAt)
+	}
+	if rep.Exhaustion != nil {
+		t.Error("the pro exhaustion estimate should be dropped")
+	}
+	if acc := rep.Accounts[0]; acc.Remaining != 80 || acc.RemainingPercentage != 80 || !acc.ResetAt.Equal(flashReset) {
+		t.Errorf("account headline should follow the flash bucket, got %+v", acc)
+	}
+
+	if NarrowModels(&models.ProviderReport{Models: buckets}, "ultra") {
+		t.Error("expected no match for ultra")
+	}
+}
@@ -2,11 +2,16 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(_ Settings) Provider { return &GoogleAIStudioProvider{} })
+}
+
 // GoogleAIStudioProvider implements the Google AI Studio fetcher
 type GoogleAIStudioProvider struct{}
 
@@ -14,16 +19,40 @@ func (c *GoogleAIStudioProvider) Name() string {
 	return "Google AI Studio"
 }
 
+func (c *GoogleAIStudioProvider) ID() string {
+	return "aistudio"
+}
+
 func (c *GoogleAIStudioProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
+func (c *GoogleAIStudioProvider) Aliases() []string {
+	return []string{"google-ai-studio"}
+}
+
+// KeyNames lists where the Google AI Studio key is stored in auth.json;
+// users often name the nested entry "google" or "google-custom".
+func (c *GoogleAIStudioProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "googleaistudio.key", Nested: []string{"google", "google-custom"}}
+}
+
+// Detect reports the key it finds but fails when there is one, keeping the
+// provider out of the active set until quota checking is implemented.
+func (c *GoogleAIStudioProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	res := detectKey(cfg, c.KeyNames())
+	if res.Available {
+		return res, errors.New("quota checking is not yet implemented")
+	}
+	return res, nil
+}
+
+func (c *GoogleAIStudioProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
 func (c *GoogleAIStudioProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
-	return &models.ProviderReport{
-		Name:        c.Name(),
-		Type:        c.Type(),
-		RefreshTime: "(Not Implemented)",
-	}, fmt.Errorf("Google AI Studio quota checking is not yet implemented")
+	return nil, fmt.Errorf("Google AI Studio quota checking is not yet implemented")
 }
 
 func (c *GoogleAIStudioProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
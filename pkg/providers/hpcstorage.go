@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func init() {
+	Register(func(s Settings) Provider { return &HPCStorageProvider{Config: s.Storage} })
+}
+
+// HPCStorageProvider reports filesystem quotas on HPC clusters by parsing
+// `lfs quota` (Lustre), `mmlsquota` (GPFS) or `quota -s`.
+type HPCStorageProvider struct {
+	Config hpc.StorageConfig
+	// Runner executes the quota commands; nil uses the local shell.
+	Runner hpc.Runner
+}
+
+func (h *HPCStorageProvider) Name() string {
+	return "HPC Storage"
+}
+
+func (h *HPCStorageProvider) ID() string {
+	return "storage"
+}
+
+func (h *HPCStorageProvider) Type() models.ProviderType {
+	return models.TypeQuotaBased
+}
+
+func (h *HPCStorageProvider) Aliases() []string {
+	return []string{"hpc-storage"}
+}
+
+// Detect only succeeds when the user told us which filesystems or projects
+// to check.
+func (h *HPCStorageProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	if !h.Config.Configured() {
+		return DetectResult{}, nil
+	}
+	return DetectResult{
+		Available:  true,
+		Source:     "config: hpc.storage",
+		Credential: strings.Join(append(append([]string{}, h.Config.Paths...), h.Config.Projects...), ", "),
+	}, nil
+}
+
+func (h *HPCStorageProvider) Capabilities() Capabilities {
+	return Capabilities{MultiAccount: true}
+}
+
+func (h *HPCStorageProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	runner := h.Runner
+	if runner == nil {
+		runner = hpc.ExecRunner{}
+	}
+
+	infos, err := hpc.CollectStorage(ctx, runner, h.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]models.Account, 0, len(infos))
+	for i, q := range infos {
+		accounts = append(accounts, storageAccount(i, q))
+	}
+
+	return &models.ProviderReport{
+		Name:     h.Name(),
+		Type:     h.Type(),
+		Accounts: accounts,
+	}, nil
+}
+
+func (h *HPCStorageProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
+	return nil, nil
+}
+
+// storageAccount converts a filesystem quota into an account sub-row. Sizes
+// are shown in the unit chosen by storageUnit so small quotas do not round to 0.
+func storageAccount(idx int, q hpc.QuotaInfo) models.Account {
+	unit, size := storageUnit(max(q.Limit, q.Used))
+	used := int(q.Used / size)
+	limit := int(q.Limit / size)
+	remaining := max(limit-used, 0)
+	pct := 0
+	if q.Limit > 0 {
+		pct = int(max(q.Limit-q.Used, 0) * 100 / q.Limit)
+	}
+
+	var notes []string
+	if q.FileLimit > 0 {
+		notes = append(notes, fmt.Sprintf("files %d/%d", q.Files, q.FileLimit))
+	} else if q.Files > 0 {
+		notes = append(notes, fmt.Sprintf("files %d", q.Files))
+	}
+	if q.Grace != "" {
+		notes = append(notes, "grace "+q.Grace)
+	}
+
+	return models.Account{
+		Index:               idx,
+		Email:               fmt.Sprintf("%s (%s)", q.Path, q.ProjectCode),
+		AccountID:           q.ProjectCode,
+		Remaining:           remaining,
+		Entitlement:         limit,
+		RemainingPercentage: pct,
+		Unit:                unit,
+		Note:                strings.Join(notes, ", "),
+	}
+}
+
+// storageUnits are the binary units a storage quota may be shown in, largest first.
+var storageUnits = []struct {
+	name string
+	size float64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+// storageUnit picks the largest unit in which bytes is at least 10, so whole
+// numbers keep two significant digits.
+func storageUnit(bytes float64) (string, float64) {
+	for _, u := range storageUnits {
+		if bytes >= 10*u.size {
+			return u.name, u.size
+		}
+	}
+	last := storageUnits[len(storageUnits)-1]
+	return last.name, last.size
+}
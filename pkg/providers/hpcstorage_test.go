@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+)
+
+func TestStorageAccountUnits(t *testing.T) {
+	tests := []struct {
+		used, limit         float64
+		unit                string
+		remaining, entitled int
+		remainingPercentage int
+	}{
+		// A 512 MiB home quota used to truncate to 0 GiB and show as unlimited.
+		{used: 128 << 20, limit: 512 << 20, unit: "MiB", remaining: 384, entitled: 512, remainingPercentage: 75},
+		{used: 40 << 30, limit: 100 << 30, unit: "GiB", remaining: 60, entitled: 100, remainingPercentage: 60},
+		{used: 1 << 40, limit: 20 << 40, unit: "TiB", remaining: 19, entitled: 20, remainingPercentage: 95},
+		// 1.5 TiB stays in GiB rather than rounding to 1 TiB.
+		{used: 0, limit: 1536 << 30, unit: "GiB", remaining: 1536, entitled: 1536, remainingPercentage: 100},
+	}
+	for _, tt := range tests {
+		acc := storageAccount(0, hpc.QuotaInfo{Path: "/home", ProjectCode: "alice", Used: tt.used, Limit: tt.limit, Unit: "bytes"})
+		if acc.Unit != tt.unit || acc.Remaining != tt.remaining || acc.Entitlement != tt.entitled ||
+			acc.RemainingPercentage != tt.remainingPercentage {
+			t.Errorf("storageAccount(used %.0f, limit %.0f) = %d/%d %s (%d%%), want %d/%d %s (%d%%)",
+				tt.used, tt.limit, acc.Remaining, acc.Entitlement, acc.Unit, acc.RemainingPercentage,
+				tt.remaining, tt.entitled, tt.unit, tt.remainingPercentage)
+		}
+	}
+}
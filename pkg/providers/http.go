@@ -0,0 +1,228 @@
+package providers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Defaults for HTTPConfig fields left at zero.
+const (
+	DefaultConnectTimeout = 10 * time.Second
+	DefaultRetries        = 2
+)
+
+// HTTPConfig configures the client every provider uses for its API calls.
+// Proxies are taken from HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+type HTTPConfig struct {
+	// ConnectTimeout bounds dialing and the TLS handshake.
+	ConnectTimeout time.Duration
+	// Timeout bounds a whole request, retries and reading the body included.
+	Timeout time.Duration
+	// Retries is how many times a request failing with 429 or 5xx is
+	// retried; negative disables retries.
+	Retries int
+	// CABundle is a PEM file of extra certificate authorities to trust on
+	// top of the system ones, e.g. a corporate TLS-inspecting proxy.
+	CABundle string
+	// UserAgent is sent with every request.
+	UserAgent string
+}
+
+// client is shared by all providers; ConfigureHTTP replaces it.
+var client = mustHTTPClient(HTTPConfig{})
+
+// clientConfig is what client was built from, with defaults filled in, for
+// transports that cannot use client directly such as Vertex AI's gRPC.
+var clientConfig = HTTPConfig{}.withDefaults()
+
+// ConfigureHTTP rebuilds the shared provider client from c. It must be called
+// before providers are fetched, not concurrently with them.
+func ConfigureHTTP(c HTTPConfig) error {
+	hc, err := NewHTTPClient(c)
+	if err != nil {
+		return err
+	}
+	client = hc
+	clientConfig = c.withDefaults()
+	return nil
+}
+
+// NewHTTPClient builds a client with the timeouts, retries, proxy and CA
+// settings of c.
+func NewHTTPClient(c HTTPConfig) (*http.Client, error) {
+	c = c.withDefaults()
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.Proxy = http.ProxyFromEnvironment
+	base.DialContext = (&net.Dialer{Timeout: c.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	base.TLSHandshakeTimeout = c.ConnectTimeout
+	if tlsConfig != nil {
+		base.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Timeout: c.Timeout,
+		Transport: &retryTransport{
+			base:      base,
+			retries:   max(c.Retries, 0),
+			userAgent: c.UserAgent,
+		},
+	}, nil
+}
+
+func (c HTTPConfig) withDefaults() HTTPConfig {
+	if c.ConnectTimeout <= 0 {
+		c.ConnectTimeout = DefaultConnectTimeout
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.Retries == 0 {
+		c.Retries = DefaultRetries
+	}
+	if c.UserAgent == "" {
+		c.UserAgent = "qcli"
+	}
+	return c
+}
+
+// tlsConfig trusts CABundle on top of the system roots; nil means the
+// system defaults.
+func (c HTTPConfig) tlsConfig() (*tls.Config, error) {
+	if c.CABundle == "" {
+		return nil, nil
+	}
+	pool, err := loadCABundle(c.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+func mustHTTPClient(c HTTPConfig) *http.Client {
+	hc, err := NewHTTPClient(c)
+	if err != nil {
+		panic(err)
+	}
+	return hc
+}
+
+// loadCABundle returns the system roots plus the certificates in path.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
+
+// retryTransport sets the User-Agent and retries requests that fail with 429
+// or a 5xx status, backing off exponentially with jitter or as long as the
+// server asks with Retry-After.
+type retryTransport struct {
+	base      http.RoundTripper
+	retries   int
+	userAgent string
+	// backoff returns the wait before retry n (0-based); nil uses expBackoff.
+	backoff func(n int) time.Duration
+}
+
+// maxRetryWait caps both the exponential backoff and Retry-After.
+const maxRetryWait = 30 * time.Second
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	for n := 0; ; n++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || n >= t.retries || !retryable(resp.StatusCode) {
+			return resp, err
+		}
+		// A request body can only be sent again if it can be rewound.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			wait = t.wait(n)
+		}
+		if wait > maxRetryWait {
+			return resp, nil
+		}
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *retryTransport) wait(n int) time.Duration {
+	if t.backoff != nil {
+		return t.backoff(n)
+	}
+	return expBackoff(n)
+}
+
+// expBackoff doubles from 500ms per retry, capped at maxRetryWait, and picks
+// a random wait in the upper half of that so clients do not retry in lockstep.
+func expBackoff(n int) time.Duration {
+	d := min(500*time.Millisecond<<n, maxRetryWait)
+	return d/2 + rand.N(d/2+1)
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,126 @@
+package providers
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	var calls int
+	var bodies, agents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		agents = append(agents, r.UserAgent())
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	hc, err := NewHTTPClient(HTTPConfig{UserAgent: "qcli/test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc.Transport.(*retryTransport).backoff = func(int) time.Duration { return 0 }
+
+	resp, err := hc.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("got status %d after %d calls, want 200 after 3", resp.StatusCode, calls)
+	}
+	for i := range bodies {
+		if bodies[i] != "payload" || agents[i] != "qcli/test" {
+			t.Errorf("attempt %d sent body %q with User-Agent %q", i+1, bodies[i], agents[i])
+		}
+	}
+
+	// Out of retries: the last error response is returned as is.
+	calls = 0
+	hc, _ = NewHTTPClient(HTTPConfig{Retries: -1})
+	resp, err = hc.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("got status %d after %d calls with retries disabled", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"Sun, 01 Mar 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"Sun, 01 Mar 2026 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExpBackoffStaysInRange(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		d := min(500*time.Millisecond<<n, maxRetryWait)
+		if got := expBackoff(n); got < d/2 || got > d {
+			t.Errorf("expBackoff(%d) = %v, want between %v and %v", n, got, d/2, d)
+		}
+	}
+}
+
+// Vertex AI's gRPC client cannot use the shared client, so ConfigureHTTP must
+// leave it the same CA bundle and User-Agent.
+func TestConfigureHTTPSharesTLSWithGRPC(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	prevClient, prevConfig := client, clientConfig
+	defer func() { client, clientConfig = prevClient, prevConfig }()
+	if err := ConfigureHTTP(HTTPConfig{CABundle: bundle, UserAgent: "qcli/test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if clientConfig.UserAgent != "qcli/test" {
+		t.Errorf("UserAgent = %q, want qcli/test", clientConfig.UserAgent)
+	}
+	tlsConfig, err := clientConfig.tlsConfig()
+	if err != nil || tlsConfig == nil {
+		t.Fatalf("tlsConfig() = %v, %v", tlsConfig, err)
+	}
+	if _, err := srv.Certificate().Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs}); err != nil {
+		t.Errorf("CA bundle not trusted: %v", err)
+	}
+}
@@ -11,35 +11,61 @@ import (
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider { return &OpenAIProvider{BaseURL: s.BaseURLs["openai"]} })
+}
+
 // OpenAIProvider implements the OpenAI Quota-based fetcher
-type OpenAIProvider struct{}
+type OpenAIProvider struct {
+	// BaseURL replaces openAIBaseURL when set.
+	BaseURL string
+}
+
+const openAIBaseURL = "https://chatgpt.com/backend-api"
 
 func (c *OpenAIProvider) Name() string {
 	return "OpenAI"
 }
 
+func (c *OpenAIProvider) ID() string {
+	return "openai"
+}
+
 func (c *OpenAIProvider) Type() models.ProviderType {
 	return models.TypeQuotaBased
 }
 
+func (c *OpenAIProvider) Aliases() []string {
+	return []string{"chatgpt"}
+}
+
+// KeyNames lists where the OpenAI token is stored in auth.json.
+func (c *OpenAIProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "openai.key", Nested: []string{"openai"}}
+}
+
+func (c *OpenAIProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	return detectKey(cfg, c.KeyNames()), nil
+}
+
+func (c *OpenAIProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
 func (c *OpenAIProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
 	token := cfg.GetNestedField("openai", "access")
 	if token == "" {
-		token = cfg.GetKey(c.Name())
+		token = cfg.GetKey(c.KeyNames())
 	}
 
 	// If no real token, do not return mock data
 	if token == "" || token == "sk-openai-mock" {
-		return &models.ProviderReport{
-			Name:        c.Name(),
-			Type:        models.TypeQuotaBased,
-			RefreshTime: "Token Missing or Mock",
-		}, fmt.Errorf("invalid or missing OpenAI token")
+		return nil, fmt.Errorf("invalid or missing OpenAI token")
 	}
 
 	accountID := cfg.GetNestedField("openai", "accountId")
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://chatgpt.com/backend-api/wham/usage", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint(c.BaseURL, openAIBaseURL, "/wham/usage"), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +75,6 @@ func (c *OpenAIProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 		req.Header.Set("ChatGPT-Account-Id", accountID)
 	}
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -58,19 +83,13 @@ func (c *OpenAIProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 	bodyBytes, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return &models.ProviderReport{
-			Name:        c.Name(),
-			Type:        models.TypeQuotaBased,
-			RefreshTime: "Token Expired or " + string(bodyBytes),
-		}, fmt.Errorf("API failed with status %d", resp.StatusCode)
+		return nil, fmt.Errorf("API failed with status %d (token expired?)", resp.StatusCode)
 	}
 
 	var result struct {
 		RateLimit struct {
-			PrimaryWindow struct {
-				UsedPercent float64 `json:"used_percent"`
-				ResetAt     int64   `json:"reset_at"`
-			} `json:"primary_window"`
+			PrimaryWindow   openAIWindow  `json:"primary_window"`
+			SecondaryWindow *openAIWindow `json:"secondary_window"`
 		} `json:"rate_limit"`
 	}
 
@@ -78,35 +97,46 @@ func (c *OpenAIProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 		return nil, err
 	}
 
-	// Calculate used percentage
-	usage := int(result.RateLimit.PrimaryWindow.UsedPercent)
-	if usage > 100 {
-		usage = 100
-	} else if usage < 0 {
-		usage = 0
+	rep := &models.ProviderReport{
+		Name: c.Name(),
+		Type: models.TypeQuotaBased,
 	}
-	remaining := 100 - usage
-
-	// Format refresh time
-	refreshTimeStr := "Weekly" // fallback
-	if result.RateLimit.PrimaryWindow.ResetAt > 0 {
-		resetTime := time.Unix(result.RateLimit.PrimaryWindow.ResetAt, 0)
-		days := int(time.Until(resetTime).Hours() / 24)
-		if days > 0 {
-			refreshTimeStr = fmt.Sprintf("Weekly: in %dd (%s)", days, resetTime.Format("01/02"))
-		} else {
-			refreshTimeStr = fmt.Sprintf("Weekly: %s", resetTime.Format("01/02 15:04"))
-		}
+	primary := result.RateLimit.PrimaryWindow.quotaWindow("primary")
+	if primary.WindowSeconds <= 0 {
+		primary.WindowSeconds = int64(7 * 24 * time.Hour / time.Second)
 	}
+	head := primary
+	if sw := result.RateLimit.SecondaryWindow; sw != nil {
+		rep.Windows = []models.QuotaWindow{primary, sw.quotaWindow("secondary")}
+		markBinding(rep.Windows)
+		head = *bindingWindow(rep.Windows)
+	}
+	applyWindow(rep, head)
+	return rep, nil
+}
 
-	return &models.ProviderReport{
-		Name:            c.Name(),
-		Type:            models.TypeQuotaBased,
-		Remaining:       remaining,
-		Entitlement:     100,
-		UsagePercentage: usage,
-		RefreshTime:     refreshTimeStr,
-	}, nil
+// openAIWindow is one rate-limit window of the ChatGPT usage endpoint.
+type openAIWindow struct {
+	UsedPercent        float64 `json:"used_percent"`
+	ResetAt            int64   `json:"reset_at"`
+	LimitWindowSeconds int64   `json:"limit_window_seconds"`
+}
+
+// quotaWindow converts the window, naming it by its length when known.
+func (w openAIWindow) quotaWindow(fallbackName string) models.QuotaWindow {
+	qw := models.QuotaWindow{
+		Name:          fallbackName,
+		UsedPercent:   w.UsedPercent,
+		WindowSeconds: w.LimitWindowSeconds,
+	}
+	if w.LimitWindowSeconds > 0 {
+		qw.Name = windowName(time.Duration(w.LimitWindowSeconds) * time.Second)
+	}
+	if w.ResetAt > 0 {
+		t := time.Unix(w.ResetAt, 0)
+		qw.ResetAt = &t
+	}
+	return qw
 }
 
 func (c *OpenAIProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
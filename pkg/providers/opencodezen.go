@@ -16,18 +16,53 @@ import (
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider { return &OpenCodeZenProvider{BaseURL: s.BaseURLs["zen"]} })
+}
+
 // OpenCodeZenProvider fetches pay-as-you-go cost from the OpenCode Zen (api.z.ai) service.
 // Token is read from the opencode SQLite database (control_account table) or auth.json.
-type OpenCodeZenProvider struct{}
+type OpenCodeZenProvider struct {
+	// BaseURL replaces openCodeZenBaseURL when set.
+	BaseURL string
+}
+
+const openCodeZenBaseURL = "https://api.z.ai/api"
 
 func (o *OpenCodeZenProvider) Name() string {
 	return "OpenCode Zen"
 }
 
+func (o *OpenCodeZenProvider) ID() string {
+	return "zen"
+}
+
 func (o *OpenCodeZenProvider) Type() models.ProviderType {
 	return models.TypePayAsYouGo
 }
 
+func (o *OpenCodeZenProvider) Aliases() []string {
+	return []string{"opencode-zen"}
+}
+
+func (o *OpenCodeZenProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	// Only available if a real token was found in the DB during auth discovery
+	t, ok := cfg.RawKeys["opencode-zen-token"].(string)
+	if !ok || t == "" {
+		return DetectResult{}, nil
+	}
+	return DetectResult{
+		Available:   true,
+		Source:      sourceOrUnknown(cfg, "opencode-zen-token"),
+		Credential:  MaskSecret(t),
+		Fingerprint: Fingerprint(t),
+	}, nil
+}
+
+func (o *OpenCodeZenProvider) Capabilities() Capabilities {
+	return Capabilities{Cost: true}
+}
+
 func (o *OpenCodeZenProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
 	token, err := o.findToken(cfg)
 	if err != nil || token == "" {
@@ -41,9 +76,10 @@ func (o *OpenCodeZenProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAut
 	}
 
 	return &models.ProviderReport{
-		Name: o.Name(),
-		Type: o.Type(),
-		Cost: cost,
+		Name:        o.Name(),
+		Type:        o.Type(),
+		Cost:        cost,
+		MonthToDate: cost,
 	}, nil
 }
 
@@ -95,7 +131,8 @@ func (o *OpenCodeZenProvider) fetchModelUsageCost(ctx context.Context, token str
 	now := time.Now()
 	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 
-	url := fmt.Sprintf("https://api.z.ai/api/monitor/usage/model-usage?start=%s&end=%s",
+	url := fmt.Sprintf("%s?start=%s&end=%s",
+		endpoint(o.BaseURL, openCodeZenBaseURL, "/monitor/usage/model-usage"),
 		startOfMonth.Format("2006-01-02"),
 		now.Format("2006-01-02"),
 	)
@@ -106,7 +143,7 @@ func (o *OpenCodeZenProvider) fetchModelUsageCost(ctx context.Context, token str
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := (&http.Client{}).Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return 0, err
 	}
@@ -150,3 +187,21 @@ func (o *OpenCodeZenProvider) fetchModelUsageCost(ctx context.Context, token str
 func (o *OpenCodeZenProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
 	return nil, nil
 }
+
+// hasOpenCodeZenDBFile checks if the opencode.db file exists (token presence
+// is verified by auth.DiscoverOpenCodeAuth at startup, exposed via cfg.RawKeys).
+func hasOpenCodeZenDBFile() bool {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	for _, p := range []string{
+		filepath.Join(home, ".local", "share", "opencode", "opencode.db"),
+		filepath.Join(home, "Library", "Application Support", "opencode", "opencode.db"),
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -11,35 +11,61 @@ import (
 
 type openrouterResponse struct {
 	Data struct {
-		TotalUsage float64 `json:"total_usage"`
+		TotalCredits float64 `json:"total_credits"`
+		TotalUsage   float64 `json:"total_usage"`
 	} `json:"data"`
 }
 
+func init() {
+	Register(func(s Settings) Provider { return &OpenRouterProvider{BaseURL: s.BaseURLs["openrouter"]} })
+}
+
 // OpenRouterProvider implements the OpenRouter Pay-As-You-Go API fetcher
-type OpenRouterProvider struct{}
+type OpenRouterProvider struct {
+	// BaseURL replaces openRouterBaseURL when set.
+	BaseURL string
+}
+
+const openRouterBaseURL = "https://openrouter.ai/api/v1"
 
 func (o *OpenRouterProvider) Name() string {
 	return "OpenRouter"
 }
 
+func (o *OpenRouterProvider) ID() string {
+	return "openrouter"
+}
+
 func (o *OpenRouterProvider) Type() models.ProviderType {
 	return models.TypePayAsYouGo
 }
 
+// KeyNames lists where the OpenRouter key is stored in auth.json.
+func (o *OpenRouterProvider) KeyNames() models.KeyNames {
+	return models.KeyNames{Flat: "openrouter.key", Nested: []string{"openrouter"}}
+}
+
+func (o *OpenRouterProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	return detectKey(cfg, o.KeyNames()), nil
+}
+
+func (o *OpenRouterProvider) Capabilities() Capabilities {
+	return Capabilities{Cost: true}
+}
+
 func (o *OpenRouterProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
-	apiKey := cfg.GetKey(o.Name())
+	apiKey := cfg.GetKey(o.KeyNames())
 	if apiKey == "" {
 		return nil, fmt.Errorf("no API key provided")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://openrouter.ai/api/v1/credits", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint(o.BaseURL, openRouterBaseURL, "/credits"), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -55,10 +81,12 @@ func (o *OpenRouterProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuth
 		return nil, err
 	}
 
+	// total_usage is lifetime spend; month-to-date is derived from snapshots.
 	return &models.ProviderReport{
-		Name: o.Name(),
-		Type: o.Type(),
-		Cost: orResp.Data.TotalUsage,
+		Name:    o.Name(),
+		Type:    o.Type(),
+		Cost:    orResp.Data.TotalUsage,
+		Credits: orResp.Data.TotalCredits,
 	}, nil
 }
 
@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestOpenRouterFetchUsesBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gateway/credits" {
+			t.Errorf("requested %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data": {"total_credits": 25, "total_usage": 7.5}}`))
+	}))
+	defer srv.Close()
+
+	p := &OpenRouterProvider{BaseURL: srv.URL + "/gateway"}
+	rep, err := p.Fetch(context.Background(), &models.OpenCodeAuthConfig{
+		RawKeys: map[string]interface{}{"openrouter.key": "sk-or-test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Cost != 7.5 || rep.Credits != 25 {
+		t.Errorf("got cost %v and credits %v, want 7.5 and 25", rep.Cost, rep.Credits)
+	}
+}
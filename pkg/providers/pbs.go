@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func init() {
+	Register(func(s Settings) Provider { return &PBSProvider{Config: s.PBS} })
+}
+
+// PBSProvider reports remaining core-hours per PBS Pro project code, from a
+// site allocation command or from accounting logs plus running jobs.
+type PBSProvider struct {
+	Config hpc.PBSConfig
+	// Runner executes qstat or the allocation command; nil uses the local shell.
+	Runner hpc.Runner
+}
+
+func (p *PBSProvider) Name() string {
+	return "PBS Pro"
+}
+
+func (p *PBSProvider) ID() string {
+	return "pbs"
+}
+
+func (p *PBSProvider) Type() models.ProviderType {
+	return models.TypeQuotaBased
+}
+
+func (p *PBSProvider) Aliases() []string {
+	return []string{"pbs-pro"}
+}
+
+// Detect requires explicit configuration: qstat alone is not enough, as
+// Slurm and SGE ship qstat wrappers too.
+func (p *PBSProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	if !p.Config.Configured() {
+		return DetectResult{}, nil
+	}
+	res := DetectResult{
+		Available:  true,
+		Source:     "config: pbs.projects",
+		Credential: strings.Join(p.Config.Projects, ", "),
+	}
+	if p.Config.AllocationCommand != "" {
+		res.Source = "config: pbs.allocation_command"
+	}
+	return res, nil
+}
+
+func (p *PBSProvider) Capabilities() Capabilities {
+	return Capabilities{MultiAccount: true}
+}
+
+func (p *PBSProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	runner := p.Runner
+	if runner == nil {
+		runner = hpc.ExecRunner{}
+	}
+
+	infos, err := hpc.CollectPBS(ctx, runner, p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]models.Account, 0, len(infos))
+	for i, q := range infos {
+		accounts = append(accounts, allocationAccount(i, q.ProjectCode, "core-h", q.Used, q.Limit, ""))
+	}
+
+	return &models.ProviderReport{
+		Name:     p.Name(),
+		Type:     p.Type(),
+		Accounts: accounts,
+	}, nil
+}
+
+func (p *PBSProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
+	return nil, nil
+}
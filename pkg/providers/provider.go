@@ -2,6 +2,8 @@ package providers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
@@ -11,6 +13,11 @@ type Provider interface {
 	// Name returns the human-readable provider name (e.g. "OpenRouter").
 	Name() string
 
+	// ID returns the stable short identifier (e.g. "copilot") used on the
+	// command line and in configuration, cache and history keys. Unlike Name
+	// it must never change once released.
+	ID() string
+
 	// Type returns whether the provider is pay-as-you-go or quota-based.
 	Type() models.ProviderType
 
@@ -19,4 +26,111 @@ type Provider interface {
 
 	// FetchHistory retrieves historical usage data if supported by the provider.
 	FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error)
+
+	// Detect reports whether the provider's credentials are available and
+	// where they were found. A non-nil error means the provider cannot be
+	// used even if it found credentials.
+	Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error)
+
+	// Capabilities declares what the provider reports besides its quota.
+	Capabilities() Capabilities
+}
+
+// DetectResult describes the credentials a provider found.
+type DetectResult struct {
+	Available bool `json:"available"`
+	// Source is the provenance of the credential, e.g. "env: GITHUB_TOKEN"
+	// or "auth.json: /home/me/.local/share/opencode/auth.json".
+	Source string `json:"source,omitempty"`
+	// Credential is the masked secret (or a non-secret identifier such as a GCP project).
+	Credential string `json:"credential,omitempty"`
+	// Fingerprint identifies the unmasked secret behind Credential, which is
+	// ambiguous when two secrets share their first and last characters. It is
+	// a hash, so it never reveals the secret, and is left out of JSON.
+	Fingerprint string `json:"-"`
+}
+
+// Capabilities lists the optional data a provider reports.
+type Capabilities struct {
+	// History is set when FetchHistory returns daily usage.
+	History bool `json:"history,omitempty"`
+	// MultiAccount is set when reports break usage down into Accounts.
+	MultiAccount bool `json:"multiAccount,omitempty"`
+	// Cost is set when reports carry spend in USD.
+	Cost bool `json:"cost,omitempty"`
+	// Tokens is set when reports count tokens rather than requests.
+	Tokens bool `json:"tokens,omitempty"`
+}
+
+// window returns the reset instant and start of a fixed-length quota window.
+func window(resetAt time.Time, length time.Duration) (*time.Time, *time.Time) {
+	start := resetAt.Add(-length)
+	return &resetAt, &start
+}
+
+// markBinding flags the window that blocks usage first: the most used one,
+// or among equally used ones the one that resets last.
+func markBinding(windows []models.QuotaWindow) {
+	best := -1
+	for i, w := range windows {
+		windows[i].Binding = false
+		if best < 0 || w.UsedPercent > windows[best].UsedPercent ||
+			(w.UsedPercent == windows[best].UsedPercent && resetsAfter(w, windows[best])) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		windows[best].Binding = true
+	}
+}
+
+// bindingWindow returns the window markBinding flagged, or nil.
+func bindingWindow(windows []models.QuotaWindow) *models.QuotaWindow {
+	for i := range windows {
+		if windows[i].Binding {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// applyWindow makes w the report's headline figure: usage percentage,
+// remaining quota, reset instant and window kind. Providers with several
+// windows pass the binding one, so the threshold flag, exhaustion estimate
+// and snapshots follow the limit that blocks usage first.
+func applyWindow(rep *models.ProviderReport, w models.QuotaWindow) {
+	usage := min(max(int(w.UsedPercent), 0), 100)
+	rep.Entitlement, rep.Unit = 100, models.UnitPercent
+	rep.UsagePercentage, rep.Remaining = usage, 100-usage
+	length := time.Duration(w.WindowSeconds) * time.Second
+	rep.WindowKind = windowKind(length)
+	if w.ResetAt != nil {
+		rep.ResetAt, rep.WindowStart = window(*w.ResetAt, length)
+	}
+}
+
+func resetsAfter(a, b models.QuotaWindow) bool {
+	return a.ResetAt != nil && (b.ResetAt == nil || a.ResetAt.After(*b.ResetAt))
+}
+
+// windowKind classifies a quota window by its length: daily, weekly, monthly
+// or, for other lengths, its name such as "5-hour".
+func windowKind(length time.Duration) string {
+	switch {
+	case length == 24*time.Hour:
+		return models.WindowDaily
+	case length == 7*24*time.Hour:
+		return models.WindowWeekly
+	case length >= 28*24*time.Hour && length <= 31*24*time.Hour:
+		return models.WindowMonthly
+	}
+	return windowName(length)
+}
+
+// windowName labels a window by its length, e.g. "5-hour" or "7-day".
+func windowName(length time.Duration) string {
+	if length >= 24*time.Hour && length%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d-day", length/(24*time.Hour))
+	}
+	return fmt.Sprintf("%d-hour", int(length.Round(time.Hour).Hours()))
 }
@@ -1,35 +1,58 @@
 package providers
 
 import (
-	"context"
-	"os"
-	"path/filepath"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
-	"golang.org/x/oauth2/google"
 )
 
-// GetActiveProviders discovers which providers have credentials available
-// and returns only those — no hardcoded assumptions about the user's setup.
-func GetActiveProviders(cfg *models.OpenCodeAuthConfig, provFlag string) []Provider {
-	// Full catalog of supported providers
-	catalog := []Provider{
-		&AntigravityProvider{},
-		&OpenAIProvider{},
-		&OpenRouterProvider{},
-		&CopilotProvider{},
-		&VertexProvider{},
-		&OpenCodeZenProvider{},
+// registry holds the factory of every provider added with Register.
+var registry []func(Settings) Provider
+
+// Register makes a provider available to qcli. It is meant to be called from
+// the init function of the file that implements the provider, so adding a
+// provider needs no other change. Register panics if the provider's ID or one
+// of its aliases is already taken.
+func Register(factory func(Settings) Provider) {
+	p := factory(Settings{})
+	for _, f := range registry {
+		other := f(Settings{})
+		for _, taken := range providerKeys(other) {
+			for _, key := range providerKeys(p) {
+				if key == taken {
+					panic(fmt.Sprintf("providers: %s and %s both answer to %q", other.Name(), p.Name(), key))
+				}
+			}
+		}
+	}
+	registry = append(registry, factory)
+}
+
+// catalog returns every registered provider built from s, sorted by name.
+func catalog(s Settings) []Provider {
+	all := make([]Provider, 0, len(registry))
+	for _, f := range registry {
+		all = append(all, f(s))
 	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
 
+// GetActiveProviders discovers which providers have credentials available
+// and returns only those — no hardcoded assumptions about the user's setup.
+func GetActiveProviders(cfg *models.OpenCodeAuthConfig, s Settings, sel Selection) []Provider {
 	var active []Provider
-	for _, p := range catalog {
-		// Apply --provider filter if given
-		if provFlag != "" && p.Name() != provFlag {
+	for _, p := range catalog(s) {
+		// Apply --provider and --exclude
+		if !sel.Match(p.Name()) {
 			continue
 		}
 
-		if isProviderAvailable(p, cfg) {
+		if res, err := p.Detect(cfg); err == nil && res.Available {
 			active = append(active, p)
 		}
 	}
@@ -37,58 +60,81 @@ func GetActiveProviders(cfg *models.OpenCodeAuthConfig, provFlag string) []Provi
 	return active
 }
 
-// isProviderAvailable probes each provider's credential sources to determine
-// if it should be included in the active set.
-func isProviderAvailable(p Provider, cfg *models.OpenCodeAuthConfig) bool {
-	switch p.Name() {
-
-	case "Vertex AI":
-		_, err := google.FindDefaultCredentials(context.Background(), "https://www.googleapis.com/auth/cloud-platform")
-		return err == nil
-
-	case "Antigravity":
-		hasAnthropic := cfg.GetNestedField("anthropic", "access") != "" || cfg.GetNestedField("anthropic", "key") != ""
-		hasGemini := cfg.GetKey("Gemini CLI") != "" || hasAntigravityConfig(cfg)
-		return hasAnthropic || hasGemini
-
-	case "GitHub Copilot":
-		return cfg.GetNestedField("github-copilot", "access") != "" ||
-			cfg.GetNestedField("github-copilot", "refresh") != ""
+// Detection describes whether a provider's credentials were found and where.
+type Detection struct {
+	Name string `json:"name"`
+	// ID and Aliases are what --provider and --exclude accept.
+	ID      string   `json:"id"`
+	Aliases []string `json:"aliases,omitempty"`
+	DetectResult
+	Capabilities Capabilities `json:"capabilities"`
+	// Error explains why a provider cannot be used even though it may have
+	// found credentials.
+	Error string `json:"error,omitempty"`
+}
 
-	case "OpenCode Zen":
-		// Only mark as available if a real token was found in the DB during auth discovery
-		t, ok := cfg.RawKeys["opencode-zen-token"].(string)
-		return ok && t != ""
+// DetectProviders reports, for every provider in the catalog, whether it is
+// available and where its credential came from.
+func DetectProviders(cfg *models.OpenCodeAuthConfig, s Settings) []Detection {
+	var out []Detection
+	for _, p := range catalog(s) {
+		keys := providerKeys(p)
+		d := Detection{Name: p.Name(), ID: keys[0], Aliases: keys[1:], Capabilities: p.Capabilities()}
+		res, err := p.Detect(cfg)
+		d.DetectResult = res
+		if err != nil {
+			d.Available = false
+			d.Error = err.Error()
+		}
+		out = append(out, d)
+	}
+	return out
+}
 
-	default:
-		return cfg.GetKey(p.Name()) != ""
+// detectKey is the Detect implementation of providers whose only credential
+// is an API key or token stored under names.
+func detectKey(cfg *models.OpenCodeAuthConfig, names models.KeyNames) DetectResult {
+	key, rawKey := cfg.LookupKey(names)
+	if key == "" {
+		return DetectResult{}
+	}
+	return DetectResult{
+		Available:   true,
+		Source:      sourceOrUnknown(cfg, rawKey),
+		Credential:  MaskSecret(key),
+		Fingerprint: Fingerprint(key),
 	}
 }
 
-// hasAntigravityConfig checks if an antigravity refresh token is available.
-func hasAntigravityConfig(cfg *models.OpenCodeAuthConfig) bool {
-	ant, ok := cfg.RawKeys["antigravity"].(map[string]interface{})
-	if !ok {
-		return false
+// MaskSecret hides all but a few leading and trailing characters of a credential.
+func MaskSecret(s string) string {
+	if s == "" {
+		return ""
 	}
-	_, hasRefresh := ant["refresh_token"]
-	return hasRefresh
+	if len(s) < 12 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + "…" + s[len(s)-4:]
+}
+
+// Fingerprint hashes credentials into an identifier for DetectResult.
+func Fingerprint(secrets ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(secrets, "\x00")))
+	return hex.EncodeToString(sum[:])
 }
 
-// hasOpenCodeZenDBFile checks if the opencode.db file exists (token presence
-// is verified by auth.DiscoverOpenCodeAuth at startup, exposed via cfg.RawKeys).
-func hasOpenCodeZenDBFile() bool {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return false
+func sourceOrUnknown(cfg *models.OpenCodeAuthConfig, rawKey string) string {
+	if src := cfg.Source(rawKey); src != "" {
+		return src
 	}
-	for _, p := range []string{
-		filepath.Join(home, ".local", "share", "opencode", "opencode.db"),
-		filepath.Join(home, "Library", "Application Support", "opencode", "opencode.db"),
-	} {
-		if _, err := os.Stat(p); err == nil {
-			return true
-		}
+	return "unknown (" + rawKey + ")"
+}
+
+// Names returns the display names of every provider in the catalog.
+func Names() []string {
+	var names []string
+	for _, p := range catalog(Settings{}) {
+		names = append(names, p.Name())
 	}
-	return false
+	return names
 }
@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func TestCatalogIncludesEveryProviderFile(t *testing.T) {
+	names := make(map[string]bool)
+	for _, name := range Names() {
+		if names[name] {
+			t.Errorf("%s registered twice", name)
+		}
+		names[name] = true
+	}
+	for _, want := range []string{"Claude", "Gemini CLI", "Google AI Studio", "OpenAI", "Slurm"} {
+		if !names[want] {
+			t.Errorf("%s is not registered", want)
+		}
+	}
+}
+
+func TestDetectProvidersUsesProviderDetect(t *testing.T) {
+	cfg := &models.OpenCodeAuthConfig{
+		RawKeys: map[string]interface{}{
+			"claude.key": "sk-ant-0123456789abcdef",
+			"google":     map[string]interface{}{"key": "AIza0123456789abcdef"},
+		},
+		Sources: map[string]string{"claude.key": "env: ANTHROPIC_API_KEY"},
+	}
+	got := make(map[string]Detection)
+	for _, d := range DetectProviders(cfg, Settings{}) {
+		got[d.Name] = d
+	}
+
+	claude := got["Claude"]
+	if !claude.Available || claude.Source != "env: ANTHROPIC_API_KEY" || claude.Credential != "sk-a…cdef" {
+		t.Errorf("Claude detection = %+v", claude)
+	}
+	// A key alone does not make an unimplemented provider usable.
+	if studio := got["Google AI Studio"]; studio.Available || studio.Error == "" {
+		t.Errorf("Google AI Studio detection = %+v, want an error", studio)
+	}
+	if got["OpenRouter"].Available {
+		t.Error("OpenRouter detected without a key")
+	}
+	if !got["Vertex AI"].Capabilities.History {
+		t.Error("Vertex AI should declare history")
+	}
+
+	for _, p := range GetActiveProviders(cfg, Settings{}, Selection{}) {
+		if p.Name() == "Google AI Studio" {
+			t.Error("Google AI Studio is active despite its Detect error")
+		}
+	}
+}
+
+func TestCredentialActivatesOneProvider(t *testing.T) {
+	tests := []struct {
+		name string
+		keys map[string]interface{}
+		want string
+	}{
+		{"gemini key", map[string]interface{}{"gemini.key": "ya29.0123456789abcdef"}, "Gemini CLI"},
+		{"claude key", map[string]interface{}{"claude": map[string]interface{}{"access": "sk-ant-0123456789abcdef"}}, "Claude"},
+		{"anthropic oauth", map[string]interface{}{"anthropic": map[string]interface{}{"access": "sk-ant-REDACTED"}}, "Antigravity"},
+		{"antigravity refresh", map[string]interface{}{"antigravity": map[string]interface{}{"refresh_token": "1//0123456789abcdef"}}, "Antigravity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &models.OpenCodeAuthConfig{RawKeys: tt.keys}
+			var active []string
+			for _, d := range DetectProviders(cfg, Settings{}) {
+				// Only the providers that could share these credentials;
+				// others depend on the machine (ADC, sacct on PATH).
+				shared := d.Name == "Claude" || d.Name == "Gemini CLI" || d.Name == "Antigravity"
+				if shared && d.Available {
+					active = append(active, d.Name)
+				}
+			}
+			if len(active) != 1 || active[0] != tt.want {
+				t.Errorf("active providers = %v, want [%s]", active, tt.want)
+			}
+		})
+	}
+}
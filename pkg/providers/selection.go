@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Aliaser is implemented by providers that answer to other names besides
+// their ID, e.g. "github-copilot" for GitHub Copilot, whose ID is "copilot".
+type Aliaser interface {
+	Aliases() []string
+}
+
+// IDs returns the ID of every provider in the catalog, sorted.
+func IDs() []string {
+	var ids []string
+	for _, f := range registry {
+		ids = append(ids, f(Settings{}).ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Resolve returns the ID of the provider whose ID or alias is key, ignoring
+// case, so that aliases can be used wherever an ID is expected.
+func Resolve(key string) (string, bool) {
+	if p := lookup(key); p != nil {
+		return p.ID(), true
+	}
+	return "", false
+}
+
+// NameOf returns the display name of the provider with the given ID or
+// alias, or id itself when there is none.
+func NameOf(id string) string {
+	if p := lookup(id); p != nil {
+		return p.Name()
+	}
+	return id
+}
+
+// Keys returns every name the provider with the given ID or alias answers
+// to: its ID followed by its aliases. An unknown id is returned on its own.
+func Keys(id string) []string {
+	if p := lookup(id); p != nil {
+		return providerKeys(p)
+	}
+	return []string{id}
+}
+
+// lookup returns the provider whose ID or alias is key, or nil.
+func lookup(key string) Provider {
+	key = normalizePattern(key)
+	for _, f := range registry {
+		p := f(Settings{})
+		for _, k := range providerKeys(p) {
+			if k == key {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+func providerKeys(p Provider) []string {
+	keys := []string{p.ID()}
+	if a, ok := p.(Aliaser); ok {
+		keys = append(keys, a.Aliases()...)
+	}
+	return keys
+}
+
+// Selection narrows the catalog to the providers picked on the command line.
+// Patterns are matched against a provider's ID, aliases and display name,
+// ignoring case, and may be shell globs such as "open*".
+type Selection struct {
+	// Include keeps only matching providers; empty means all of them.
+	Include []string
+	// Exclude drops matching providers, even if Include matches them too.
+	Exclude []string
+}
+
+// Match reports whether the named provider is selected.
+func (s Selection) Match(name string) bool {
+	if len(s.Include) > 0 && !matchAny(s.Include, name) {
+		return false
+	}
+	return !matchAny(s.Exclude, name)
+}
+
+// Validate checks that every pattern is well formed and matches at least one
+// supported provider, so typos fail loudly instead of selecting nothing.
+func (s Selection) Validate() error {
+	names := Names()
+	for _, pattern := range append(append([]string{}, s.Include...), s.Exclude...) {
+		if _, err := path.Match(normalizePattern(pattern), ""); err != nil {
+			return fmt.Errorf("invalid provider pattern %q: %w", pattern, err)
+		}
+		found := false
+		for _, name := range names {
+			found = found || matchAny([]string{pattern}, name)
+		}
+		if !found {
+			return fmt.Errorf("unknown provider %q (supported: %s)", pattern, strings.Join(IDs(), ", "))
+		}
+	}
+	return nil
+}
+
+// matchAny reports whether any pattern matches one of the provider's keys or
+// its display name.
+func matchAny(patterns []string, name string) bool {
+	var keys []string
+	for _, f := range registry {
+		if p := f(Settings{}); p.Name() == name {
+			keys = providerKeys(p)
+		}
+	}
+	keys = append(keys, strings.ToLower(name))
+	for _, pattern := range patterns {
+		pattern = normalizePattern(pattern)
+		for _, key := range keys {
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizePattern(pattern string) string {
+	return strings.ToLower(strings.TrimSpace(pattern))
+}
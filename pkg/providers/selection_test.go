@@ -0,0 +1,58 @@
+package providers
+
+import "testing"
+
+func TestSelectionMatch(t *testing.T) {
+	tests := []struct {
+		sel  Selection
+		name string
+		want bool
+	}{
+		{Selection{}, "GitHub Copilot", true},
+		{Selection{Include: []string{"copilot"}}, "GitHub Copilot", true},
+		{Selection{Include: []string{"GITHUB-COPILOT"}}, "GitHub Copilot", true},
+		{Selection{Include: []string{"GitHub Copilot"}}, "GitHub Copilot", true},
+		{Selection{Include: []string{"claude", "vertex"}}, "Vertex AI", true},
+		{Selection{Include: []string{"open*"}}, "OpenRouter", true},
+		{Selection{Include: []string{"open*"}, Exclude: []string{"openrouter"}}, "OpenRouter", false},
+		{Selection{Exclude: []string{"zen"}}, "OpenCode Zen", false},
+		{Selection{Include: []string{"claude"}}, "Antigravity", false},
+	}
+	for _, tt := range tests {
+		if got := tt.sel.Match(tt.name); got != tt.want {
+			t.Errorf("%+v.Match(%q) = %v, want %v", tt.sel, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelectionValidate(t *testing.T) {
+	if err := (Selection{Include: []string{"copilot", "open*"}, Exclude: []string{"Vertex"}}).Validate(); err != nil {
+		t.Errorf("valid selection rejected: %v", err)
+	}
+	for _, bad := range []string{"copliot", "nothing*", "["} {
+		if err := (Selection{Include: []string{bad}}).Validate(); err == nil {
+			t.Errorf("Validate accepted %q", bad)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := map[string]string{
+		"copilot":        "copilot",
+		"github-copilot": "copilot",
+		"VERTEX-AI":      "vertex",
+		"gemini-cli":     "gemini",
+		"pbs-pro":        "pbs",
+	}
+	for key, want := range tests {
+		if got, ok := Resolve(key); !ok || got != want {
+			t.Errorf("Resolve(%q) = %q, %v, want %q", key, got, ok, want)
+		}
+	}
+	if id, ok := Resolve("GitHub Copilot"); ok {
+		t.Errorf("Resolve matched a display name: %q", id)
+	}
+	if got := NameOf("vertex"); got != "Vertex AI" {
+		t.Errorf("NameOf(vertex) = %q", got)
+	}
+}
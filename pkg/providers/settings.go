@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+)
+
+// Settings carries user configuration that some providers need beyond
+// credentials, such as which HPC filesystems to inspect.
+type Settings struct {
+	Storage hpc.StorageConfig
+	Slurm   hpc.SlurmConfig
+	PBS     hpc.PBSConfig
+	// Location is the time zone that daily history is bucketed in.
+	Location *time.Location
+	// BaseURLs overrides the API base URL of providers, keyed by provider ID.
+	BaseURLs map[string]string
+	// TokenURLs overrides the OAuth token endpoint of providers that refresh
+	// their own access tokens, keyed by provider ID.
+	TokenURLs map[string]string
+}
+
+// endpoint joins path to baseURL, or to def when baseURL is empty.
+func endpoint(baseURL, def, path string) string {
+	if baseURL == "" {
+		baseURL = def
+	}
+	return strings.TrimSuffix(baseURL, "/") + path
+}
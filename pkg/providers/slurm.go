@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JValdivia23/quota-cli/pkg/hpc"
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+func init() {
+	Register(func(s Settings) Provider { return &SlurmProvider{Config: s.Slurm} })
+}
+
+// SlurmProvider reports Slurm account allocations: core-hours and GPU-hours
+// consumed this period against GrpTRESMins, plus the user's fairshare factor.
+type SlurmProvider struct {
+	Config hpc.SlurmConfig
+	// Runner executes sshare/sacct/sacctmgr; nil uses the local shell.
+	Runner hpc.Runner
+}
+
+func (s *SlurmProvider) Name() string {
+	return "Slurm"
+}
+
+func (s *SlurmProvider) ID() string {
+	return "slurm"
+}
+
+func (s *SlurmProvider) Type() models.ProviderType {
+	return models.TypeQuotaBased
+}
+
+// Detect uses the configured accounts, or sshare being on PATH.
+func (s *SlurmProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	if len(s.Config.Accounts) > 0 {
+		return DetectResult{
+			Available:  true,
+			Source:     "config: slurm.accounts",
+			Credential: strings.Join(s.Config.Accounts, ", "),
+		}, nil
+	}
+	if path := hpc.Which("sshare"); path != "" {
+		return DetectResult{Available: true, Source: "PATH: " + path}, nil
+	}
+	return DetectResult{}, nil
+}
+
+func (s *SlurmProvider) Capabilities() Capabilities {
+	return Capabilities{MultiAccount: true}
+}
+
+func (s *SlurmProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
+	runner := s.Runner
+	if runner == nil {
+		runner = hpc.ExecRunner{}
+	}
+
+	allocs, err := hpc.CollectSlurm(ctx, runner, s.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []models.Account
+	for _, a := range allocs {
+		accounts = append(accounts, allocationAccount(len(accounts), a.Account, "core-h", a.CoreHours, a.CoreHoursLimit,
+			fmt.Sprintf("fairshare %.3f", a.FairShare)))
+		if a.GPUHours > 0 || a.GPUHoursLimit > 0 {
+			accounts = append(accounts, allocationAccount(len(accounts), a.Account, "GPU-h", a.GPUHours, a.GPUHoursLimit, ""))
+		}
+	}
+
+	return &models.ProviderReport{
+		Name:     s.Name(),
+		Type:     s.Type(),
+		Accounts: accounts,
+	}, nil
+}
+
+func (s *SlurmProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
+	return nil, nil
+}
+
+// allocationAccount builds one compute-allocation sub-row. Without a limit the consumption
+// is shown in the note since there is nothing to count down from.
+func allocationAccount(idx int, account, unit string, used, limit float64, note string) models.Account {
+	acc := models.Account{
+		Index:     idx,
+		Email:     fmt.Sprintf("%s (%s)", account, unit),
+		AccountID: account,
+		Unit:      unit,
+	}
+	var notes []string
+	if limit > 0 {
+		acc.Entitlement = int(limit)
+		acc.Remaining = max(int(limit-used), 0)
+		acc.RemainingPercentage = acc.Remaining * 100 / acc.Entitlement
+	} else {
+		notes = append(notes, fmt.Sprintf("%.0f %s used", used, unit))
+	}
+	if note != "" {
+		notes = append(notes, note)
+	}
+	acc.Note = strings.Join(notes, ", ")
+	return acc
+}
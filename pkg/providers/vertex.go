@@ -4,32 +4,116 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
 	"time"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3"
 	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/JValdivia23/quota-cli/pkg/models"
 )
 
+func init() {
+	Register(func(s Settings) Provider {
+		return &VertexProvider{Location: s.Location, BaseURL: s.BaseURLs["vertex"]}
+	})
+}
+
 // VertexProvider implements the Vertex AI token fetcher via Google Cloud Monitoring
-type VertexProvider struct{}
+type VertexProvider struct {
+	// Location sets the day boundaries of the usage history; nil means local time.
+	Location *time.Location
+	// BaseURL replaces the Cloud Monitoring API endpoint when set, e.g. a
+	// Private Service Connect address. Only its host and port are used, since
+	// the API is called over gRPC.
+	BaseURL string
+}
+
+// vertexScope is the OAuth scope Cloud Monitoring reads need.
+const vertexScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// credentials finds Application Default Credentials whose token exchange goes
+// through the shared client, so it gets the same proxy, CA bundle and
+// User-Agent as every other provider call.
+func (c *VertexProvider) credentials(ctx context.Context) (*google.Credentials, error) {
+	return google.FindDefaultCredentials(context.WithValue(ctx, oauth2.HTTPClient, client), vertexScope)
+}
+
+// metricClient connects to Cloud Monitoring with creds, honouring BaseURL
+// and the shared client's CA bundle and User-Agent.
+func (c *VertexProvider) metricClient(ctx context.Context, creds *google.Credentials) (*monitoring.MetricClient, error) {
+	opts := []option.ClientOption{
+		option.WithCredentials(creds),
+		option.WithUserAgent(clientConfig.UserAgent),
+	}
+	tlsConfig, err := clientConfig.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, option.WithGRPCDialOption(grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))))
+	}
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		host := u.Host
+		if u.Port() == "" {
+			host += ":443"
+		}
+		opts = append(opts, option.WithEndpoint(host))
+	}
+	return monitoring.NewMetricClient(ctx, opts...)
+}
 
 func (c *VertexProvider) Name() string {
 	return "Vertex AI"
 }
 
+func (c *VertexProvider) ID() string {
+	return "vertex"
+}
+
 func (c *VertexProvider) Type() models.ProviderType {
 	return models.TypeTokensBased
 }
 
+func (c *VertexProvider) Aliases() []string {
+	return []string{"vertex-ai"}
+}
+
+// Detect looks for Application Default Credentials.
+func (c *VertexProvider) Detect(cfg *models.OpenCodeAuthConfig) (DetectResult, error) {
+	creds, err := google.FindDefaultCredentials(context.Background(), vertexScope)
+	if err != nil {
+		return DetectResult{}, nil
+	}
+	res := DetectResult{Available: true, Source: "ADC: " + adcSource(creds.JSON)}
+	if creds.ProjectID != "" {
+		res.Credential = "project " + creds.ProjectID
+	}
+	return res, nil
+}
+
+func (c *VertexProvider) Capabilities() Capabilities {
+	return Capabilities{History: true, Tokens: true}
+}
+
 func (c *VertexProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConfig) (*models.ProviderReport, error) {
 	// Discover standard cloud-platform credentials
-	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	creds, err := c.credentials(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not find default GCP credentials: %w", err)
 	}
@@ -49,15 +133,19 @@ func (c *VertexProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 		return nil, fmt.Errorf("could not determine GCP Project ID from credentials")
 	}
 
-	client, err := monitoring.NewMetricClient(ctx)
+	client, err := c.metricClient(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create monitoring client: %w", err)
 	}
 	defer client.Close()
 
-	// Calculate start of current month
-	now := time.Now()
-	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	// Calculate start of current month in the configured time zone
+	loc := c.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
 
 	// Query for tokens used
 	req := &monitoringpb.ListTimeSeriesRequest{
@@ -90,19 +178,23 @@ func (c *VertexProvider) Fetch(ctx context.Context, cfg *models.OpenCodeAuthConf
 		}
 	}
 
-	history, _ := fetchVertexHistory(ctx, client, projectID)
+	history, _ := fetchVertexHistory(ctx, client, projectID, c.Location)
 
+	// The token count is month-to-date, so the window is the calendar month.
+	resetAt := startOfMonth.AddDate(0, 1, 0)
 	return &models.ProviderReport{
 		Name:        c.Name(),
 		Type:        c.Type(),
 		TokensUsed:  totalTokens,
-		RefreshTime: "Monthly",
+		WindowKind:  models.WindowMonthly,
+		ResetAt:     &resetAt,
+		WindowStart: &startOfMonth,
 		History:     history,
 	}, nil
 }
 
 func (c *VertexProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeAuthConfig) ([]models.DailyUsage, error) {
-	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	creds, err := c.credentials(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -121,18 +213,25 @@ func (c *VertexProvider) FetchHistory(ctx context.Context, cfg *models.OpenCodeA
 		return nil, fmt.Errorf("no project ID found in credentials")
 	}
 
-	client, err := monitoring.NewMetricClient(ctx)
+	client, err := c.metricClient(ctx, creds)
 	if err != nil {
 		return nil, err
 	}
 	defer client.Close()
-	return fetchVertexHistory(ctx, client, projectID)
+	return fetchVertexHistory(ctx, client, projectID, c.Location)
 }
 
-func fetchVertexHistory(ctx context.Context, client *monitoring.MetricClient, projectID string) ([]models.DailyUsage, error) {
-	now := time.Now()
-	start := now.AddDate(0, 0, -7)
+func fetchVertexHistory(ctx context.Context, client *monitoring.MetricClient, projectID string, loc *time.Location) ([]models.DailyUsage, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	y, m, d := now.Date()
+	start := time.Date(y, m, d-6, 0, 0, 0, 0, loc)
 
+	// Daily alignment would give 24-hour buckets ending at the request's end
+	// time, not at local midnights, so fetch hourly sums and bucket them by
+	// local date instead.
 	req := &monitoringpb.ListTimeSeriesRequest{
 		Name:   "projects/" + projectID,
 		Filter: `metric.type="aiplatform.googleapis.com/generate_content/total_token_count"`,
@@ -141,16 +240,14 @@ func fetchVertexHistory(ctx context.Context, client *monitoring.MetricClient, pr
 			EndTime:   timestamppb.New(now),
 		},
 		Aggregation: &monitoringpb.Aggregation{
-			AlignmentPeriod:    durationpb.New(86400 * time.Second), // 1 day
+			AlignmentPeriod:    durationpb.New(time.Hour),
 			PerSeriesAligner:   monitoringpb.Aggregation_ALIGN_SUM,
 			CrossSeriesReducer: monitoringpb.Aggregation_REDUCE_SUM,
 		},
 	}
 
 	it := client.ListTimeSeries(ctx, req)
-
-	// Create map of days
-	dayMap := make(map[string]float64)
+	var points []*monitoringpb.Point
 	for {
 		resp, err := it.Next()
 		if err == iterator.Done {
@@ -159,21 +256,51 @@ func fetchVertexHistory(ctx context.Context, client *monitoring.MetricClient, pr
 		if err != nil {
 			return nil, err
 		}
-		for _, point := range resp.Points {
-			ts := point.GetInterval().GetEndTime().AsTime()
-			dateStr := ts.Format("2006-01-02")
-			dayMap[dateStr] += float64(point.GetValue().GetInt64Value())
+		points = append(points, resp.Points...)
+	}
+	return vertexDailyUsage(points, now, loc), nil
+}
+
+// vertexDailyUsage sums token counts into the seven local days ending at now.
+// A point is counted on the day its interval starts, so an hour ending at
+// midnight belongs to the day before.
+func vertexDailyUsage(points []*monitoringpb.Point, now time.Time, loc *time.Location) []models.DailyUsage {
+	dayMap := make(map[string]float64)
+	for _, point := range points {
+		ts := point.GetInterval().GetEndTime().AsTime().Add(-time.Nanosecond)
+		if st := point.GetInterval().GetStartTime(); st != nil && st.AsTime().Before(ts) {
+			ts = st.AsTime()
 		}
+		dayMap[ts.In(loc).Format("2006-01-02")] += float64(point.GetValue().GetInt64Value())
 	}
 
+	now = now.In(loc)
 	var history []models.DailyUsage
 	for i := 6; i >= 0; i-- {
 		d := now.AddDate(0, 0, -i).Format("2006-01-02")
-		val := dayMap[d]
 		history = append(history, models.DailyUsage{
 			Date:             d,
-			IncludedRequests: val, // We store token amount here to show activity
+			IncludedRequests: dayMap[d], // We store token amount here to show activity
 		})
 	}
-	return history, nil
+	return history
+}
+
+// adcSource explains where Application Default Credentials were loaded from,
+// mirroring the lookup order used by golang.org/x/oauth2/google.
+func adcSource(credsJSON []byte) string {
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return path + " (GOOGLE_APPLICATION_CREDENTIALS)"
+	}
+	if len(credsJSON) == 0 {
+		return "GCE metadata server"
+	}
+	if dir := os.Getenv("CLOUDSDK_CONFIG"); dir != "" {
+		return filepath.Join(dir, "application_default_credentials.json")
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
 }
@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestVertexDailyUsageUsesLocalDays(t *testing.T) {
+	loc := time.FixedZone("UTC-5", -5*3600)
+	hour := func(day, h int, tokens int64) *monitoringpb.Point {
+		start := time.Date(2026, 3, day, h, 0, 0, 0, loc)
+		return &monitoringpb.Point{
+			Interval: &monitoringpb.TimeInterval{
+				StartTime: timestamppb.New(start),
+				EndTime:   timestamppb.New(start.Add(time.Hour)),
+			},
+			Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: tokens}},
+		}
+	}
+	// 23:00-24:00 local on the 9th is already the 10th in UTC.
+	points := []*monitoringpb.Point{hour(9, 23, 100), hour(10, 0, 50), hour(10, 7, 5)}
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, loc)
+
+	got := vertexDailyUsage(points, now, loc)
+	if len(got) != 7 || got[6].Date != "2026-03-10" || got[0].Date != "2026-03-04" {
+		t.Fatalf("days = %+v, want the 7 local days ending 2026-03-10", got)
+	}
+	if got[5].IncludedRequests != 100 || got[6].IncludedRequests != 55 {
+		t.Errorf("9th = %v, 10th = %v, want 100 and 55", got[5].IncludedRequests, got[6].IncludedRequests)
+	}
+}
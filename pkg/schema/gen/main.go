@@ -0,0 +1,65 @@
+// Command gen writes the JSON Schema of the current models.SchemaVersion to
+// v<N>.json in the working directory. It refuses to change the structure of a
+// schema that was already published: bump models.SchemaVersion instead.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+	"github.com/JValdivia23/quota-cli/pkg/schema"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	docs, err := schema.ParseDocs(filepath.Join("..", "models"))
+	if err != nil {
+		return err
+	}
+	generated, err := schema.Generate(docs)
+	if err != nil {
+		return err
+	}
+
+	name := schema.FileName(models.SchemaVersion)
+	existing, err := os.ReadFile(name)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	case bytes.Equal(existing, generated):
+		return nil
+	default:
+		same, err := sameStructure(existing, generated)
+		if err != nil {
+			return err
+		}
+		if !same {
+			return fmt.Errorf("the output schema changed but %s is already published; bump models.SchemaVersion", name)
+		}
+	}
+	return os.WriteFile(name, generated, 0o644)
+}
+
+// sameStructure reports whether two schemas differ at most in descriptions.
+func sameStructure(a, b []byte) (bool, error) {
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(schema.StripDescriptions(va), schema.StripDescriptions(vb)), nil
+}
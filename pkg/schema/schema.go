@@ -0,0 +1,229 @@
+// Package schema generates and publishes the JSON Schema of qcli's --json
+// output from the Go types in pkg/models.
+package schema
+
+//go:generate go run ./gen
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// published holds the schema of every released SchemaVersion as v<N>.json.
+//
+//go:embed v*.json
+var published embed.FS
+
+// FileName returns the file the schema of a version is published as.
+func FileName(version int) string {
+	return fmt.Sprintf("v%d.json", version)
+}
+
+// Current returns the published schema of models.SchemaVersion.
+func Current() ([]byte, error) {
+	return published.ReadFile(FileName(models.SchemaVersion))
+}
+
+// Generate returns the JSON Schema of models.Output. docs maps "Type" and
+// "Type.Field" to descriptions (see ParseDocs); it may be nil.
+func Generate(docs map[string]string) ([]byte, error) {
+	g := &generator{defs: make(map[string]any), docs: docs}
+	root := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     fmt.Sprintf("https://github.com/JValdivia23/quota-cli/schema/%s", FileName(models.SchemaVersion)),
+		"title":   "qcli JSON output",
+	}
+	for k, v := range g.schemaFor(reflect.TypeOf(models.Output{})) {
+		root[k] = v
+	}
+	root["$defs"] = g.defs
+
+	b, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type generator struct {
+	defs map[string]any
+	docs map[string]string
+}
+
+// schemaFor returns the schema of t. Named structs are added to $defs and
+// referenced.
+func (g *generator) schemaFor(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schemaFor(t.Elem())
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // guards against recursive types
+			g.defs[t.Name()] = g.object(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
+
+// object describes a struct the way encoding/json marshals it: fields without
+// omitempty are required, and such slices, maps and pointers may be null.
+func (g *generator) object(t reflect.Type) map[string]any {
+	props := make(map[string]any)
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := strings.Contains(opts, "omitempty")
+
+		s := g.schemaFor(f.Type)
+		switch f.Type.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			if !omitempty {
+				s = map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+			}
+		}
+		if d := g.docs[t.Name()+"."+f.Name]; d != "" {
+			s["description"] = d
+		}
+		props[name] = s
+		if !omitempty {
+			required = append(required, name)
+		}
+	}
+
+	obj := map[string]any{"type": "object", "properties": props, "required": required}
+	if d := g.docs[t.Name()]; d != "" {
+		obj["description"] = d
+	}
+	return obj
+}
+
+// ParseDocs collects the doc comments of the types and struct fields declared
+// in the Go package in dir, keyed by "Type" and "Type.Field".
+func ParseDocs(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					if d := commentText(doc); d != "" {
+						docs[ts.Name.Name] = d
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						d := commentText(field.Doc)
+						if d == "" {
+							d = commentText(field.Comment)
+						}
+						for _, name := range field.Names {
+							// Skip section headings such as "Quota-based metrics"
+							// that do not describe the field itself.
+							if mentions(d, name.Name) {
+								docs[ts.Name.Name+"."+name.Name] = d
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// mentions reports whether doc refers to name as a whole word.
+func mentions(doc, name string) bool {
+	for _, w := range strings.FieldsFunc(doc, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if w == name {
+			return true
+		}
+	}
+	return false
+}
+
+// commentText joins a comment group into a single line.
+func commentText(cg *ast.CommentGroup) string {
+	return strings.Join(strings.Fields(cg.Text()), " ")
+}
+
+// StripDescriptions removes every "description" from a decoded schema, leaving
+// only what constrains the output.
+func StripDescriptions(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			if k != "description" {
+				out[k] = StripDescriptions(val)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = StripDescriptions(val)
+		}
+		return out
+	}
+	return v
+}
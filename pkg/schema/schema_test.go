@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/JValdivia23/quota-cli/pkg/models"
+)
+
+// TestSchemaCompatibility fails when the --json output changed without a
+// SchemaVersion bump, or when the published schema is out of date.
+func TestSchemaCompatibility(t *testing.T) {
+	docs, err := ParseDocs("../models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := Generate(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := Current()
+	if err != nil {
+		t.Fatalf("no published schema for version %d: run 'go generate ./pkg/schema'", models.SchemaVersion)
+	}
+
+	var gen, pub any
+	if err := json.Unmarshal(generated, &gen); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(published, &pub); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(StripDescriptions(gen), StripDescriptions(pub)) {
+		t.Fatalf("the JSON output of pkg/models no longer matches schema version %d: "+
+			"bump models.SchemaVersion and run 'go generate ./pkg/schema'", models.SchemaVersion)
+	}
+	if !bytes.Equal(generated, published) {
+		t.Errorf("descriptions in %s are out of date: run 'go generate ./pkg/schema'", FileName(models.SchemaVersion))
+	}
+}
+
+func TestGenerateFollowsEncodingJSON(t *testing.T) {
+	b, err := Generate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Defs map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+			Required   []string                  `json:"required"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+
+	report := s.Defs["ProviderReport"]
+	if _, ok := report.Properties["error"]; !ok {
+		t.Error("ProviderReport.ErrorMsg should appear under its json name \"error\"")
+	}
+	if !reflect.DeepEqual(report.Required, []string{"id", "name", "type"}) {
+		t.Errorf("required = %v, want only the fields without omitempty", report.Required)
+	}
+	if got := report.Properties["resetAt"]["format"]; got != "date-time" {
+		t.Errorf("resetAt format = %v, want date-time", got)
+	}
+	if _, ok := report.Properties["modelBreakdown"]; ok {
+		t.Error("Account fields leaked into ProviderReport")
+	}
+}